@@ -102,3 +102,16 @@ func fromSeed(seed [32]byte, params *network.Params) (*Account, error) {
 		address:    address,
 	}, nil
 }
+
+func viewKeyAddress(viewKey *ViewKey) (*Address, error) {
+	viewKeyC := C.CString(viewKey.String())
+	defer C.free(unsafe.Pointer(viewKeyC))
+
+	addressC := C.view_key_address(viewKeyC)
+	if addressC == nil {
+		return nil, handleCError()
+	}
+	defer C.free(unsafe.Pointer(addressC))
+
+	return ParseAddress(C.GoString(addressC))
+}
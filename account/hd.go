@@ -0,0 +1,96 @@
+package account
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"github.com/pinestreetlabs/aleo-wallet-sdk/network"
+	"strconv"
+	"strings"
+)
+
+// DefaultPath is the derivation path used when none is given.
+const DefaultPath = "m/0'"
+
+// HardenedOffset is added to an index to mark it hardened, written as 0' or 0h in a path.
+const HardenedOffset uint32 = 1 << 31
+
+// hdKey is the HMAC key of the master node, which separates Aleo seeds from other SLIP-10 trees.
+var hdKey = []byte("Aleo seed")
+
+var errInvalidPath = errors.New("invalid derivation path")
+var errNotHardened = errors.New("only hardened derivation is supported")
+
+// DeriveAccount derives the account at path from a master seed, such as "m/0'/1'".
+func DeriveAccount(master [32]byte, path string, params *network.Params) (*Account, error) {
+	seed, err := DeriveSeed(master, path)
+	if err != nil {
+		return nil, fmt.Errorf("DeriveAccount : %w", err)
+	}
+
+	return FromSeed(seed, params)
+}
+
+// DeriveSeed derives the 32 byte account seed at path from a master seed.
+// Derivation follows SLIP-10 with hardened indexes only, since an account seed has no public counterpart
+// that child keys could be derived from.
+func DeriveSeed(master [32]byte, path string) ([32]byte, error) {
+	indexes, err := ParsePath(path)
+	if err != nil {
+		return [32]byte{}, fmt.Errorf("DeriveSeed : %w", err)
+	}
+
+	key, _ := deriveKey(hdKey, master[:], indexes)
+	return key, nil
+}
+
+// ParsePath parses a derivation path such as "m/44'/0'" into its indexes, hardened ones including HardenedOffset.
+// Every index must be hardened.
+func ParsePath(path string) ([]uint32, error) {
+	parts := strings.Split(path, "/")
+	if parts[0] != "m" {
+		return nil, fmt.Errorf("%w : %s : must start with m", errInvalidPath, path)
+	}
+
+	var indexes []uint32
+	for _, part := range parts[1:] {
+		hardened := strings.HasSuffix(part, "'") || strings.HasSuffix(part, "h")
+		if !hardened {
+			return nil, fmt.Errorf("%w : %s : %s", errNotHardened, path, part)
+		}
+
+		i, err := strconv.ParseUint(part[:len(part)-1], 10, 32)
+		if err != nil || uint32(i) >= HardenedOffset {
+			return nil, fmt.Errorf("%w : %s : %s", errInvalidPath, path, part)
+		}
+
+		indexes = append(indexes, uint32(i)+HardenedOffset)
+	}
+
+	return indexes, nil
+}
+
+// deriveKey returns the SLIP-10 private key and chain code at the hardened indexes.
+func deriveKey(hmacKey, seed []byte, indexes []uint32) (key, chainCode [32]byte) {
+	mac := hmac.New(sha512.New, hmacKey)
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+	copy(key[:], sum[:32])
+	copy(chainCode[:], sum[32:])
+
+	for _, i := range indexes {
+		var data [37]byte
+		copy(data[1:33], key[:])
+		binary.BigEndian.PutUint32(data[33:], i)
+
+		mac := hmac.New(sha512.New, chainCode[:])
+		mac.Write(data[:])
+		sum := mac.Sum(nil)
+		copy(key[:], sum[:32])
+		copy(chainCode[:], sum[32:])
+	}
+
+	return key, chainCode
+}
@@ -0,0 +1,88 @@
+package account
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+)
+
+// TestDeriveKeySLIP10 checks the derivation against test vector 1 for ed25519 from SLIP-10,
+// which uses the same hardened-only scheme with a different master key.
+func TestDeriveKeySLIP10(t *testing.T) {
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+
+	tests := []struct {
+		path      string
+		chainCode string
+		key       string
+	}{
+		{"m", "90046a93de5380a72b5e45010748567d5ea02bbf6522f979e05c0d8d8ca9fffb", "2b4be7f19ee27bbf30c667b642d5f4aa69fd169872f8fc3059c08ebae2eb19e7"},
+		{"m/0'", "8b59aa11380b624e81507a27fedda59fea6d0b779a778918a2fd3590e16e9c69", "68e0fe46dfb67e368c75379acec591dad19df3cde26e63b93a8e704f1dade7a3"},
+		{"m/0'/1'", "a320425f77d1b5c2505a6b1b27382b37368ee640e3557c315416801243552f14", "b1d0bad404bf35da785a64ca1ac54b2617211d2777696fbffaf208f746ae84f2"},
+		{"m/0'/1'/2'", "2e69929e00b5ab250f49c3fb1c12f252de4fed2c1db88387094a0f8c4c9ccd6c", "92a5b23c0b8a99e37d07df3fb9966917f5d06e02ddbd909c7e184371463e9fc9"},
+		{"m/0'/1'/2'/2'", "8f6d87f93d750e0efccda017d662a1b31a266e4a6f5993b15f5c1f07f74dd5cc", "30d1dc7e5fc04c31219ab25a27ae00b50f6fd66622f6e9c913253d6511d1e662"},
+		{"m/0h/1h/2h/2h/1000000000h", "68789923a0cac2cd5a29172a475fe9e0fb14cd6adb5ad98a3fa70333e7afa230", "8f94d394a8e8fd6b1bc2f3f49f5c47e385281d5c17e65324b0f62483e37e8793"},
+	}
+
+	for _, tc := range tests {
+		indexes, err := ParsePath(tc.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		key, chainCode := deriveKey([]byte("ed25519 seed"), seed, indexes)
+		if got := hex.EncodeToString(chainCode[:]); got != tc.chainCode {
+			t.Fatalf("%s : got chain code %s want %s", tc.path, got, tc.chainCode)
+		}
+		if got := hex.EncodeToString(key[:]); got != tc.key {
+			t.Fatalf("%s : got key %s want %s", tc.path, got, tc.key)
+		}
+	}
+}
+
+func TestDeriveSeed(t *testing.T) {
+	var master [32]byte
+	for i := range master {
+		master[i] = byte(i)
+	}
+
+	tests := []struct {
+		path string
+		seed string
+	}{
+		{"m", "7b9d0a2b00b008ebe2328d32438328f4fcfb15378b4411a3e5140f69b33e1f9d"},
+		{DefaultPath, "3959b8686b1561b0766a33bdd8d6678e8a290113574e4303ae2b6563e4242ede"},
+		{"m/44'/683'/0'", "0295829b8fc669c068bb063d39ea79002e6fbae0867373e365c291161bdd2286"},
+		{"m/44h/683h/1h", "ed3d358c4e21e4f81305cf44c32c64fb1cef585fcb1c13be6bba8bc73f7b90aa"},
+	}
+
+	for _, tc := range tests {
+		seed, err := DeriveSeed(master, tc.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got := hex.EncodeToString(seed[:]); got != tc.seed {
+			t.Fatalf("%s : got %s want %s", tc.path, got, tc.seed)
+		}
+	}
+}
+
+func TestParsePathInvalid(t *testing.T) {
+	tests := map[string]error{
+		"":              errInvalidPath,
+		"0'":            errInvalidPath,
+		"m/":            errNotHardened,
+		"m/0":           errNotHardened,
+		"m/0'/1":        errNotHardened,
+		"m/'":           errInvalidPath,
+		"m/-1'":         errInvalidPath,
+		"m/2147483648'": errInvalidPath,
+	}
+
+	for path, want := range tests {
+		if _, err := ParsePath(path); !errors.Is(err, want) {
+			t.Fatalf("%q : got %v want %v", path, err, want)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package account
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"testing"
+)
+
+// TestPBKDF2 checks the test vectors from RFC 6070.
+func TestPBKDF2(t *testing.T) {
+	tests := []struct {
+		iter int
+		key  string
+	}{
+		{1, "0c60c80f961f0e71f3a9b524af6012062fe037a6"},
+		{2, "ea6c014dc72d6f8ccd1ed92ace1d41f0d8de8957"},
+		{4096, "4b007901b765489abead49d926f721d065a429c1"},
+	}
+
+	for _, tc := range tests {
+		key := pbkdf2(sha1.New, []byte("password"), []byte("salt"), tc.iter, 20)
+		if got := hex.EncodeToString(key); got != tc.key {
+			t.Fatalf("%d : got %s want %s", tc.iter, got, tc.key)
+		}
+	}
+
+	key := pbkdf2(sha1.New, []byte("passwordPASSWORDpassword"), []byte("saltSALTsaltSALTsaltSALTsaltSALTsalt"), 4096, 25)
+	if got, want := hex.EncodeToString(key), "3d2eec4fe41c849b80c8d83662c0e44a8b291a964cf2f07038"; got != want {
+		t.Fatalf("got %s want %s", got, want)
+	}
+}
+
+// TestScrypt checks the test vectors from RFC 7914.
+func TestScrypt(t *testing.T) {
+	tests := []struct {
+		password string
+		salt     string
+		n, r, p  int
+		key      string
+	}{
+		{"", "", 16, 1, 1, "77d6576238657b203b19ca42c18a0497f16b4844e3074ae8dfdffa3fede21442fcd0069ded0948f8326a753a0fc81f17e8d3e0fb2e0d3628cf35e20c38d18906"},
+		{"password", "NaCl", 1024, 8, 16, "fdbabe1c9d3472007856e7190d01e9fe7c6ad7cbc8237830e77376634b3731622eaf30d92e22a3886ff109279d9830dac727afb94a83ee6d8360cbdfa2cc0640"},
+	}
+
+	for _, tc := range tests {
+		key, err := scrypt([]byte(tc.password), []byte(tc.salt), tc.n, tc.r, tc.p, 64)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got := hex.EncodeToString(key); got != tc.key {
+			t.Fatalf("%q : got %s want %s", tc.password, got, tc.key)
+		}
+	}
+}
+
+func TestScryptInvalid(t *testing.T) {
+	for _, n := range []int{0, 1, 3, 1000, 1 << 28} {
+		if _, err := scrypt(nil, nil, n, 8, 1, 32); !errors.Is(err, errInvalidScryptParams) {
+			t.Fatalf("%d : got %v want %v", n, err, errInvalidScryptParams)
+		}
+	}
+}
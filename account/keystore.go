@@ -0,0 +1,197 @@
+package account
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// KeystoreVersion is the keystore format written by EncryptKeystore.
+const KeystoreVersion = 1
+
+// Scrypt cost parameters. StandardScryptN uses 128 MiB of memory and about a second of CPU time per attempt.
+const (
+	StandardScryptN = 1 << 17
+	LightScryptN    = 1 << 12
+	scryptR         = 8
+	keystoreKeyLen  = 32
+)
+
+// maxScryptN and maxScryptP bound the cost of opening a keystore, whose parameters are untrusted input.
+// The block size r must be scryptR.
+const (
+	maxScryptN = 1 << 20
+	maxScryptP = 16
+)
+
+// ErrInvalidPassword is returned by DecryptKeystore when the password is wrong or the keystore was modified.
+var ErrInvalidPassword = errors.New("invalid password or corrupt keystore")
+
+var errUnsupportedKeystore = errors.New("unsupported keystore")
+
+// Keystore is a password-encrypted account. The address and label are stored in the clear
+// and authenticated along with the encrypted account.
+type Keystore struct {
+	Version int            `json:"version"`
+	Address string         `json:"address"`
+	Label   string         `json:"label,omitempty"`
+	Crypto  KeystoreCrypto `json:"crypto"`
+}
+
+// KeystoreCrypto holds the encrypted account and the parameters needed to decrypt it.
+type KeystoreCrypto struct {
+	Cipher     string       `json:"cipher"`
+	CipherText string       `json:"ciphertext"`
+	Nonce      string       `json:"nonce"`
+	KDF        string       `json:"kdf"`
+	KDFParams  ScryptParams `json:"kdfparams"`
+}
+
+// ScryptParams are the scrypt parameters of a keystore.
+type ScryptParams struct {
+	N      int    `json:"n"`
+	R      int    `json:"r"`
+	P      int    `json:"p"`
+	KeyLen int    `json:"dklen"`
+	Salt   string `json:"salt"`
+}
+
+// KeystoreConfig configures EncryptKeystore.
+type KeystoreConfig struct {
+	// ScryptN is the scrypt cost, a power of two up to 2^20. Defaults to StandardScryptN.
+	ScryptN int
+	// ScryptP is the scrypt parallelism, up to 16. Defaults to 1.
+	ScryptP int
+	// Label is an optional name stored in the clear.
+	Label string
+}
+
+// EncryptKeystore encrypts acc with a key derived from password with scrypt, using AES-256-GCM.
+func EncryptKeystore(acc *Account, password string, cfg *KeystoreConfig) (*Keystore, error) {
+	if cfg == nil {
+		cfg = &KeystoreConfig{}
+	}
+
+	params := ScryptParams{N: cfg.ScryptN, R: scryptR, P: cfg.ScryptP, KeyLen: keystoreKeyLen}
+	if params.N == 0 {
+		params.N = StandardScryptN
+	}
+	if params.P == 0 {
+		params.P = 1
+	}
+
+	var salt [32]byte
+	if _, err := rand.Read(salt[:]); err != nil {
+		return nil, fmt.Errorf("EncryptKeystore : %w", err)
+	}
+	params.Salt = hex.EncodeToString(salt[:])
+
+	ks := &Keystore{
+		Version: KeystoreVersion,
+		Address: acc.Address().String(),
+		Label:   cfg.Label,
+		Crypto: KeystoreCrypto{
+			Cipher:    "aes-256-gcm",
+			KDF:       "scrypt",
+			KDFParams: params,
+		},
+	}
+
+	aead, err := ks.aead(password)
+	if err != nil {
+		return nil, fmt.Errorf("EncryptKeystore : %w", err)
+	}
+
+	plaintext, err := json.Marshal(acc)
+	if err != nil {
+		return nil, fmt.Errorf("EncryptKeystore : %w", err)
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("EncryptKeystore : %w", err)
+	}
+
+	ks.Crypto.Nonce = hex.EncodeToString(nonce)
+	ks.Crypto.CipherText = hex.EncodeToString(aead.Seal(nil, nonce, plaintext, ks.additionalData()))
+
+	return ks, nil
+}
+
+// DecryptKeystore decrypts the account in ks with password.
+func DecryptKeystore(ks *Keystore, password string) (*Account, error) {
+	if ks.Version != KeystoreVersion {
+		return nil, fmt.Errorf("DecryptKeystore : %w : version %d", errUnsupportedKeystore, ks.Version)
+	}
+	if ks.Crypto.Cipher != "aes-256-gcm" || ks.Crypto.KDF != "scrypt" {
+		return nil, fmt.Errorf("DecryptKeystore : %w : %s %s", errUnsupportedKeystore, ks.Crypto.KDF, ks.Crypto.Cipher)
+	}
+
+	aead, err := ks.aead(password)
+	if err != nil {
+		return nil, fmt.Errorf("DecryptKeystore : %w", err)
+	}
+
+	nonce, err := hex.DecodeString(ks.Crypto.Nonce)
+	if err != nil || len(nonce) != aead.NonceSize() {
+		return nil, fmt.Errorf("DecryptKeystore : %w : invalid nonce", errUnsupportedKeystore)
+	}
+
+	ciphertext, err := hex.DecodeString(ks.Crypto.CipherText)
+	if err != nil {
+		return nil, fmt.Errorf("DecryptKeystore : %w : invalid ciphertext", errUnsupportedKeystore)
+	}
+
+	plaintext, err := aead.Open(nil, nonce, ciphertext, ks.additionalData())
+	if err != nil {
+		return nil, fmt.Errorf("DecryptKeystore : %w", ErrInvalidPassword)
+	}
+
+	acc := &Account{}
+	if err := json.Unmarshal(plaintext, acc); err != nil {
+		return nil, fmt.Errorf("DecryptKeystore : %w", err)
+	}
+
+	if acc.Address().String() != ks.Address {
+		return nil, fmt.Errorf("DecryptKeystore : %w : address mismatch", ErrInvalidPassword)
+	}
+
+	return acc, nil
+}
+
+// aead derives the key of ks from password.
+func (ks *Keystore) aead(password string) (cipher.AEAD, error) {
+	params := ks.Crypto.KDFParams
+	if params.KeyLen != keystoreKeyLen {
+		return nil, fmt.Errorf("%w : dklen %d", errUnsupportedKeystore, params.KeyLen)
+	}
+	if params.N > maxScryptN || params.R != scryptR || params.P > maxScryptP {
+		return nil, fmt.Errorf("%w : scrypt n %d r %d p %d", errUnsupportedKeystore, params.N, params.R, params.P)
+	}
+
+	salt, err := hex.DecodeString(params.Salt)
+	if err != nil {
+		return nil, fmt.Errorf("%w : invalid salt", errUnsupportedKeystore)
+	}
+
+	key, err := scrypt([]byte(password), salt, params.N, params.R, params.P, params.KeyLen)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// additionalData authenticates the fields of ks stored in the clear.
+func (ks *Keystore) additionalData() []byte {
+	return []byte(fmt.Sprintf("%d\x00%s\x00%s", ks.Version, ks.Address, ks.Label))
+}
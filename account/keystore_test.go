@@ -0,0 +1,100 @@
+package account
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func testAccount(t *testing.T) *Account {
+	t.Helper()
+
+	acc := &Account{}
+	if err := json.Unmarshal([]byte(`{
+		"privatekey": "APrivateKey1zkp8cC4jgHEBnbtu3xxs1Ndja2EMizcvTRDq5Nikdkukg1p",
+		"viewkey": "AViewKey1iAf6a7fv6ELA4ECwAth1hDNUJJNNoWNThmREjpybqder",
+		"address": "aleo1d5hg2z3ma00382pngntdp68e74zv54jdxy249qhaujhks9c72yrs33ddah"
+	}`), acc); err != nil {
+		t.Fatal(err)
+	}
+
+	return acc
+}
+
+// flipHex flips the lowest bit of the first byte of a hex string.
+func flipHex(s string) string {
+	buf, _ := hex.DecodeString(s)
+	buf[0] ^= 1
+	return hex.EncodeToString(buf)
+}
+
+func TestKeystore(t *testing.T) {
+	acc := testAccount(t)
+
+	ks, err := EncryptKeystore(acc, "hunter2", &KeystoreConfig{ScryptN: LightScryptN, Label: "cold"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if ks.Address != acc.Address().String() || ks.Label != "cold" {
+		t.Fatalf("got address %s label %s", ks.Address, ks.Label)
+	}
+
+	buf, err := json.Marshal(ks)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded Keystore
+	if err := json.Unmarshal(buf, &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := DecryptKeystore(&decoded, "hunter2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.PrivateKey().String() != acc.PrivateKey().String() {
+		t.Fatalf("got %s want %s", got.PrivateKey(), acc.PrivateKey())
+	}
+
+	if _, err := DecryptKeystore(&decoded, "hunter3"); !errors.Is(err, ErrInvalidPassword) {
+		t.Fatalf("got %v want %v", err, ErrInvalidPassword)
+	}
+}
+
+func TestKeystoreTampered(t *testing.T) {
+	acc := testAccount(t)
+
+	tests := map[string]struct {
+		tamper func(ks *Keystore)
+		want   error
+	}{
+		"address":      {func(ks *Keystore) { ks.Address = "aleo1" }, ErrInvalidPassword},
+		"label":        {func(ks *Keystore) { ks.Label = "hot" }, ErrInvalidPassword},
+		"ciphertext":   {func(ks *Keystore) { ks.Crypto.CipherText = flipHex(ks.Crypto.CipherText) }, ErrInvalidPassword},
+		"salt":         {func(ks *Keystore) { ks.Crypto.KDFParams.Salt = flipHex(ks.Crypto.KDFParams.Salt) }, ErrInvalidPassword},
+		"version":      {func(ks *Keystore) { ks.Version = 2 }, errUnsupportedKeystore},
+		"cipher":       {func(ks *Keystore) { ks.Crypto.Cipher = "aes-128-ctr" }, errUnsupportedKeystore},
+		"scrypt n":     {func(ks *Keystore) { ks.Crypto.KDFParams.N = 1000 }, errInvalidScryptParams},
+		"scrypt max n": {func(ks *Keystore) { ks.Crypto.KDFParams.N = 1 << 21 }, errUnsupportedKeystore},
+		"scrypt max p": {func(ks *Keystore) { ks.Crypto.KDFParams.P = 1 << 20 }, errUnsupportedKeystore},
+		"scrypt r":     {func(ks *Keystore) { ks.Crypto.KDFParams.N, ks.Crypto.KDFParams.R = 2, 1<<22 }, errUnsupportedKeystore},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			ks, err := EncryptKeystore(acc, "hunter2", &KeystoreConfig{ScryptN: LightScryptN})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			tc.tamper(ks)
+			if _, err := DecryptKeystore(ks, "hunter2"); !errors.Is(err, tc.want) {
+				t.Fatalf("got %v want %v", err, tc.want)
+			}
+		})
+	}
+}
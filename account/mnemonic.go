@@ -0,0 +1,181 @@
+package account
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	_ "embed"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// MnemonicWords is the number of words in a mnemonic, 256 bits of seed and 8 bits of checksum.
+const MnemonicWords = 24
+
+// mnemonicSalt and mnemonicIter parameterize the passphrase key, which masks the seed before it is encoded.
+const (
+	mnemonicSalt = "nemean mnemonic"
+	mnemonicIter = 2048
+)
+
+// englishWords is the BIP-39 English wordlist.
+//
+//go:embed english.txt
+var englishWords string
+
+var wordlist = strings.Fields(englishWords)
+
+var wordIndex = func() map[string]int {
+	m := make(map[string]int, len(wordlist))
+	for i, w := range wordlist {
+		m[w] = i
+	}
+	return m
+}()
+
+var errInvalidMnemonicLen = errors.New("invalid mnemonic length")
+var errInvalidChecksum = errors.New("invalid mnemonic checksum")
+
+// UnknownWordError is returned when a mnemonic contains a word that is not in the wordlist.
+type UnknownWordError struct {
+	// Position is the 1-based position of the word in the mnemonic.
+	Position int
+	Word     string
+	// Suggestions are the closest words in the wordlist.
+	Suggestions []string
+}
+
+// Error implements the error interface.
+func (e *UnknownWordError) Error() string {
+	if len(e.Suggestions) == 0 {
+		return fmt.Sprintf("unknown word %d %q", e.Position, e.Word)
+	}
+	return fmt.Sprintf("unknown word %d %q, did you mean %s?", e.Position, e.Word, strings.Join(e.Suggestions, ", "))
+}
+
+// NewMnemonic encodes a 32 byte seed as a 24 word mnemonic from the BIP-39 English wordlist.
+// Without a passphrase the words are the BIP-39 encoding of the seed itself. With one, the seed is masked
+// with a key derived from the passphrase, so the words alone do not reveal the seed.
+func NewMnemonic(seed [32]byte, passphrase string) string {
+	entropy := maskSeed(seed, passphrase)
+	sum := sha256.Sum256(entropy[:])
+
+	// 264 bits, the seed followed by the first byte of its hash, split into 11 bit words.
+	bits := append(entropy[:], sum[0])
+	words := make([]string, MnemonicWords)
+	for i := range words {
+		var idx int
+		for j := 0; j < 11; j++ {
+			bit := i*11 + j
+			idx = idx<<1 | int(bits[bit/8]>>(7-uint(bit%8))&1)
+		}
+		words[i] = wordlist[idx]
+	}
+
+	return strings.Join(words, " ")
+}
+
+// SeedFromMnemonic decodes a mnemonic created by NewMnemonic with the same passphrase.
+// Words are matched exactly after lowercasing; an unknown word returns an *UnknownWordError with suggestions.
+// A wrong passphrase cannot be detected and yields a different seed, so check the resulting address.
+func SeedFromMnemonic(mnemonic, passphrase string) ([32]byte, error) {
+	words := strings.Fields(strings.ToLower(mnemonic))
+	if len(words) != MnemonicWords {
+		return [32]byte{}, fmt.Errorf("SeedFromMnemonic : %w : got %d words want %d", errInvalidMnemonicLen, len(words), MnemonicWords)
+	}
+
+	var bits [33]byte
+	for i, w := range words {
+		idx, ok := wordIndex[w]
+		if !ok {
+			return [32]byte{}, fmt.Errorf("SeedFromMnemonic : %w", &UnknownWordError{Position: i + 1, Word: w, Suggestions: suggestWords(w)})
+		}
+
+		for j := 0; j < 11; j++ {
+			if idx>>(10-uint(j))&1 == 1 {
+				bit := i*11 + j
+				bits[bit/8] |= 1 << (7 - uint(bit%8))
+			}
+		}
+	}
+
+	var entropy [32]byte
+	copy(entropy[:], bits[:32])
+	if sum := sha256.Sum256(entropy[:]); sum[0] != bits[32] {
+		return [32]byte{}, fmt.Errorf("SeedFromMnemonic : %w", errInvalidChecksum)
+	}
+
+	return maskSeed(entropy, passphrase), nil
+}
+
+// maskSeed XORs seed with a key derived from passphrase. It is its own inverse and a no-op without a passphrase.
+func maskSeed(seed [32]byte, passphrase string) [32]byte {
+	if passphrase == "" {
+		return seed
+	}
+
+	key := pbkdf2(sha512.New, []byte(passphrase), []byte(mnemonicSalt), mnemonicIter, len(seed))
+	for i := range seed {
+		seed[i] ^= key[i]
+	}
+	return seed
+}
+
+// suggestWords returns up to three words within an edit distance of two of w, closest first,
+// or the words sharing its first four letters, which identify a word uniquely.
+func suggestWords(w string) []string {
+	var res []string
+	for d := 1; d <= 2 && len(res) < 3; d++ {
+		for _, candidate := range wordlist {
+			if editDistance(w, candidate) == d {
+				res = append(res, candidate)
+				if len(res) == 3 {
+					break
+				}
+			}
+		}
+	}
+
+	if len(res) == 0 && len(w) >= 4 {
+		for _, candidate := range wordlist {
+			if strings.HasPrefix(candidate, w[:4]) {
+				res = append(res, candidate)
+			}
+		}
+	}
+
+	return res
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min3(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+
+	return prev[len(b)]
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
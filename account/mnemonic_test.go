@@ -0,0 +1,115 @@
+package account
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// TestNewMnemonic checks the 256 bit entropy vectors from BIP-39, which apply without a passphrase.
+func TestNewMnemonic(t *testing.T) {
+	tests := []struct {
+		b        byte
+		mnemonic string
+	}{
+		{0x00, strings.Repeat("abandon ", 23) + "art"},
+		{0x7f, "legal winner thank year wave sausage worth useful legal winner thank year wave sausage worth useful legal winner thank year wave sausage worth title"},
+		{0x80, "letter advice cage absurd amount doctor acoustic avoid letter advice cage absurd amount doctor acoustic avoid letter advice cage absurd amount doctor acoustic bless"},
+		{0xff, strings.Repeat("zoo ", 23) + "vote"},
+	}
+
+	for _, tc := range tests {
+		var seed [32]byte
+		copy(seed[:], bytes.Repeat([]byte{tc.b}, 32))
+
+		mnemonic := NewMnemonic(seed, "")
+		if mnemonic != tc.mnemonic {
+			t.Fatalf("%x : got %s want %s", tc.b, mnemonic, tc.mnemonic)
+		}
+
+		got, err := SeedFromMnemonic(mnemonic, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != seed {
+			t.Fatalf("%x : got %x want %x", tc.b, got, seed)
+		}
+	}
+}
+
+func TestMnemonicPassphrase(t *testing.T) {
+	var seed [32]byte
+	for i := range seed {
+		seed[i] = byte(i)
+	}
+
+	mnemonic := NewMnemonic(seed, "correct horse")
+	if mnemonic == NewMnemonic(seed, "") {
+		t.Fatal("passphrase did not change the mnemonic")
+	}
+
+	// Case and spacing are not significant.
+	got, err := SeedFromMnemonic("  "+strings.ToUpper(mnemonic)+"\n", "correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != seed {
+		t.Fatalf("got %x want %x", got, seed)
+	}
+
+	// A wrong passphrase decodes to a different seed.
+	got, err = SeedFromMnemonic(mnemonic, "correct horse battery")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got == seed {
+		t.Fatal("wrong passphrase decoded to the seed")
+	}
+}
+
+func TestSeedFromMnemonicInvalid(t *testing.T) {
+	tests := map[string]error{
+		"":                                   errInvalidMnemonicLen,
+		strings.Repeat("abandon ", 23):       errInvalidMnemonicLen,
+		strings.Repeat("abandon ", 25):       errInvalidMnemonicLen,
+		strings.Repeat("abandon ", 24):       errInvalidChecksum,
+		strings.Repeat("zoo ", 23) + "wrong": errInvalidChecksum,
+	}
+
+	for mnemonic, want := range tests {
+		if _, err := SeedFromMnemonic(mnemonic, ""); !errors.Is(err, want) {
+			t.Fatalf("%q : got %v want %v", mnemonic, err, want)
+		}
+	}
+}
+
+func TestSeedFromMnemonicUnknownWord(t *testing.T) {
+	tests := []struct {
+		word        string
+		suggestions []string
+	}{
+		{"abandom", []string{"abandon", "random"}},
+		{"letterr", []string{"letter", "better", "lottery"}},
+		{"abandonment", []string{"abandon"}},
+		{"qqqqq", nil},
+	}
+
+	for _, tc := range tests {
+		words := strings.Fields(strings.Repeat("zoo ", 23) + "vote")
+		words[4] = tc.word
+
+		_, err := SeedFromMnemonic(strings.Join(words, " "), "")
+		var wordErr *UnknownWordError
+		if !errors.As(err, &wordErr) {
+			t.Fatalf("%s : got %v want UnknownWordError", tc.word, err)
+		}
+
+		if wordErr.Position != 5 || wordErr.Word != tc.word {
+			t.Fatalf("%s : got word %d %s", tc.word, wordErr.Position, wordErr.Word)
+		}
+		if strings.Join(wordErr.Suggestions, " ") != strings.Join(tc.suggestions, " ") {
+			t.Fatalf("%s : got suggestions %v want %v", tc.word, wordErr.Suggestions, tc.suggestions)
+		}
+	}
+}
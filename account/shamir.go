@@ -0,0 +1,195 @@
+package account
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"github.com/btcsuite/btcutil/bech32"
+)
+
+// sharePrefix is the human-readable prefix of an encoded share.
+const sharePrefix = "aleoshare"
+
+// shareVersion is the share format written by Share.String.
+const shareVersion = 1
+
+// MaxShares is the largest number of shares a seed can be split into.
+const MaxShares = 255
+
+var errInvalidThreshold = errors.New("invalid threshold")
+var errInvalidShare = errors.New("invalid share")
+var errTooFewShares = errors.New("not enough shares")
+var errShareMismatch = errors.New("shares are from different splits")
+var errDuplicateShare = errors.New("duplicate share")
+var errShareDigest = errors.New("combined seed does not match the share digest")
+
+// Share is one share of a seed split with SplitSeed. Any Threshold shares of a split recover the seed.
+// Fewer shares reveal nothing about it beyond Digest, which every share carries.
+type Share struct {
+	// Threshold is the number of shares needed to recover the seed.
+	Threshold int
+	// Index is the x coordinate of the share, from 1.
+	Index int
+	// Digest is the start of the SHA-256 hash of the seed, shared by every share of a split.
+	// It identifies the split and checks the combined seed. It is a 32-bit fingerprint of the seed,
+	// so it also confirms a guessed seed to anyone holding a single share.
+	Digest [4]byte
+	Value  [32]byte
+}
+
+// String implements the stringer interface for Share.
+// Returns a bech32m encoded string whose checksum detects corrupted shares.
+func (s Share) String() string {
+	buf := []byte{shareVersion, byte(s.Threshold), byte(s.Index)}
+	buf = append(buf, s.Digest[:]...)
+	buf = append(buf, s.Value[:]...)
+
+	data, err := bech32.ConvertBits(buf, 8, 5, true)
+	if err != nil {
+		return ""
+	}
+
+	share, _ := bech32.EncodeM(sharePrefix, data)
+	return share
+}
+
+// ParseShare decodes a share created by Share.String.
+func ParseShare(share string) (*Share, error) {
+	hrp, data, version, err := bech32.DecodeGeneric(share)
+	if err != nil {
+		return nil, fmt.Errorf("ParseShare : %w : %v", errInvalidShare, err)
+	}
+
+	if hrp != sharePrefix || version != bech32.VersionM {
+		return nil, fmt.Errorf("ParseShare : %w : not a bech32m %s string", errInvalidShare, sharePrefix)
+	}
+
+	buf, err := bech32.ConvertBits(data, 5, 8, false)
+	if err != nil {
+		return nil, fmt.Errorf("ParseShare : %w : %v", errInvalidShare, err)
+	}
+
+	if len(buf) != 39 || buf[0] != shareVersion {
+		return nil, fmt.Errorf("ParseShare : %w : unsupported format", errInvalidShare)
+	}
+
+	s := &Share{Threshold: int(buf[1]), Index: int(buf[2])}
+	copy(s.Digest[:], buf[3:7])
+	copy(s.Value[:], buf[7:])
+
+	if s.Threshold < 2 || s.Index == 0 {
+		return nil, fmt.Errorf("ParseShare : %w : threshold %d index %d", errInvalidShare, s.Threshold, s.Index)
+	}
+
+	return s, nil
+}
+
+// SplitSeed splits seed into n shares with Shamir's secret sharing over GF(256), any threshold of which recover it.
+func SplitSeed(seed [32]byte, threshold, n int) ([]Share, error) {
+	if threshold < 2 || threshold > n || n > MaxShares {
+		return nil, fmt.Errorf("SplitSeed : %w : %d of %d", errInvalidThreshold, threshold, n)
+	}
+
+	// Each byte of the seed is the constant term of a random polynomial of degree threshold-1.
+	coeffs := make([]byte, len(seed)*(threshold-1))
+	if _, err := rand.Read(coeffs); err != nil {
+		return nil, fmt.Errorf("SplitSeed : %w", err)
+	}
+
+	digest := seedDigest(seed)
+	shares := make([]Share, n)
+	for i := range shares {
+		x := byte(i + 1)
+		shares[i] = Share{Threshold: threshold, Index: int(x), Digest: digest}
+
+		for j := range seed {
+			// Horner's method, highest coefficient first.
+			var y byte
+			for k := threshold - 2; k >= 0; k-- {
+				y = gfMul(y, x) ^ coeffs[j*(threshold-1)+k]
+			}
+			shares[i].Value[j] = gfMul(y, x) ^ seed[j]
+		}
+	}
+
+	return shares, nil
+}
+
+// CombineShares recovers the seed from at least Threshold shares of the same split.
+// Every share given is used, so a corrupted share among them fails the digest check.
+func CombineShares(shares []Share) ([32]byte, error) {
+	if len(shares) == 0 {
+		return [32]byte{}, fmt.Errorf("CombineShares : %w : got 0", errTooFewShares)
+	}
+
+	first := shares[0]
+	seen := make(map[int]bool, len(shares))
+	for _, s := range shares {
+		if s.Threshold != first.Threshold || s.Digest != first.Digest {
+			return [32]byte{}, fmt.Errorf("CombineShares : %w", errShareMismatch)
+		}
+		if s.Index < 1 || s.Index > MaxShares {
+			return [32]byte{}, fmt.Errorf("CombineShares : %w : index %d", errInvalidShare, s.Index)
+		}
+		if seen[s.Index] {
+			return [32]byte{}, fmt.Errorf("CombineShares : %w : index %d", errDuplicateShare, s.Index)
+		}
+		seen[s.Index] = true
+	}
+
+	if len(shares) < first.Threshold {
+		return [32]byte{}, fmt.Errorf("CombineShares : %w : got %d want %d", errTooFewShares, len(shares), first.Threshold)
+	}
+
+	// Lagrange interpolation at x = 0, where subtraction in GF(256) is XOR.
+	var seed [32]byte
+	for i, si := range shares {
+		basis := byte(1)
+		for j, sj := range shares {
+			if i != j {
+				basis = gfMul(basis, gfDiv(byte(sj.Index), byte(sj.Index)^byte(si.Index)))
+			}
+		}
+
+		for k := range seed {
+			seed[k] ^= gfMul(si.Value[k], basis)
+		}
+	}
+
+	digest := seedDigest(seed)
+	if subtle.ConstantTimeCompare(digest[:], first.Digest[:]) != 1 {
+		return [32]byte{}, fmt.Errorf("CombineShares : %w", errShareDigest)
+	}
+
+	return seed, nil
+}
+
+func seedDigest(seed [32]byte) [4]byte {
+	var digest [4]byte
+	sum := sha256.Sum256(seed[:])
+	copy(digest[:], sum[:])
+	return digest
+}
+
+// gfMul multiplies in GF(256) with the AES polynomial, without branching on secret values.
+func gfMul(a, b byte) byte {
+	var p byte
+	for i := 0; i < 8; i++ {
+		p ^= -(b & 1) & a
+		// Reduce by x^8 + x^4 + x^3 + x + 1 when the high bit shifts out.
+		a = a<<1 ^ -(a>>7)&0x1b
+		b >>= 1
+	}
+	return p
+}
+
+// gfDiv divides a by b, which must not be zero, in GF(256) using b^254 as the inverse of b.
+func gfDiv(a, b byte) byte {
+	inv := b
+	for i := 0; i < 6; i++ {
+		inv = gfMul(gfMul(inv, inv), b)
+	}
+	return gfMul(a, gfMul(inv, inv))
+}
@@ -0,0 +1,140 @@
+package account
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestGFMul(t *testing.T) {
+	// Examples from FIPS-197: {57}{83} = {c1} and {53} is the inverse of {ca}.
+	if got := gfMul(0x57, 0x83); got != 0xc1 {
+		t.Fatalf("got %x want c1", got)
+	}
+	if got := gfDiv(1, 0xca); got != 0x53 {
+		t.Fatalf("got %x want 53", got)
+	}
+
+	for a := 1; a < 256; a++ {
+		for b := 1; b < 256; b++ {
+			if got := gfDiv(gfMul(byte(a), byte(b)), byte(b)); got != byte(a) {
+				t.Fatalf("%x * %x / %x : got %x", a, b, b, got)
+			}
+		}
+	}
+}
+
+func TestSplitSeed(t *testing.T) {
+	var seed [32]byte
+	for i := range seed {
+		seed[i] = byte(i)
+	}
+
+	shares, err := SplitSeed(seed, 3, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Every subset of three or more shares recovers the seed.
+	for mask := 0; mask < 1<<len(shares); mask++ {
+		var subset []Share
+		for i, s := range shares {
+			if mask&(1<<i) != 0 {
+				subset = append(subset, s)
+			}
+		}
+		if len(subset) < 3 {
+			continue
+		}
+
+		got, err := CombineShares(subset)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != seed {
+			t.Fatalf("%b : got %x want %x", mask, got, seed)
+		}
+	}
+
+	if _, err := CombineShares(shares[:2]); !errors.Is(err, errTooFewShares) {
+		t.Fatalf("got %v want %v", err, errTooFewShares)
+	}
+}
+
+func TestSplitSeedInvalid(t *testing.T) {
+	tests := [][2]int{{1, 3}, {4, 3}, {2, 256}, {0, 0}}
+	for _, tc := range tests {
+		if _, err := SplitSeed([32]byte{}, tc[0], tc[1]); !errors.Is(err, errInvalidThreshold) {
+			t.Fatalf("%d of %d : got %v want %v", tc[0], tc[1], err, errInvalidThreshold)
+		}
+	}
+}
+
+func TestCombineSharesInvalid(t *testing.T) {
+	var seed [32]byte
+	seed[0] = 1
+
+	shares, err := SplitSeed(seed, 2, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	other, err := SplitSeed([32]byte{}, 2, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	corrupt := shares[1]
+	corrupt.Value[0] ^= 1
+
+	tests := map[string]struct {
+		shares []Share
+		want   error
+	}{
+		"mixed":     {[]Share{shares[0], other[1]}, errShareMismatch},
+		"duplicate": {[]Share{shares[0], shares[0]}, errDuplicateShare},
+		"corrupt":   {[]Share{shares[0], corrupt}, errShareDigest},
+		"extra":     {[]Share{shares[0], shares[2], corrupt}, errShareDigest},
+	}
+
+	for name, tc := range tests {
+		if _, err := CombineShares(tc.shares); !errors.Is(err, tc.want) {
+			t.Fatalf("%s : got %v want %v", name, err, tc.want)
+		}
+	}
+}
+
+func TestParseShare(t *testing.T) {
+	shares, err := SplitSeed([32]byte{1, 2, 3}, 2, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	encoded := shares[1].String()
+	if !strings.HasPrefix(encoded, sharePrefix+"1") {
+		t.Fatalf("got %s", encoded)
+	}
+
+	got, err := ParseShare(strings.ToUpper(encoded))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *got != shares[1] {
+		t.Fatalf("got %+v want %+v", got, shares[1])
+	}
+
+	// Change one character of the data part.
+	i := len(sharePrefix) + 10
+	c := "q"
+	if encoded[i] == 'q' {
+		c = "p"
+	}
+	typo := encoded[:i] + c + encoded[i+1:]
+	if _, err := ParseShare(typo); !errors.Is(err, errInvalidShare) {
+		t.Fatalf("got %v want %v", err, errInvalidShare)
+	}
+
+	if _, err := ParseShare("aleo1d5hg2z3ma00382pngntdp68e74zv54jdxy249qhaujhks9c72yrs33ddah"); !errors.Is(err, errInvalidShare) {
+		t.Fatalf("got %v want %v", err, errInvalidShare)
+	}
+}
@@ -0,0 +1,89 @@
+package account
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+var errViewKeyMismatch = errors.New("view key does not match address")
+
+// Viewer is implemented by Account and WatchOnly, and is all that is needed to decrypt and scan records.
+type Viewer interface {
+	ViewKey() *ViewKey
+	Address() *Address
+}
+
+// WatchOnly is an account without a private key. It can decrypt and scan records but not spend them.
+type WatchOnly struct {
+	viewKey *ViewKey
+	address *Address
+}
+
+// WatchOnlyJSON is a helper struct for serialization. It never holds a private key.
+type WatchOnlyJSON struct {
+	ViewKey string `json:"viewkey"`
+	Address string `json:"address"`
+}
+
+// NewWatchOnly creates a WatchOnly account, checking that address is derived from viewKey.
+func NewWatchOnly(viewKey *ViewKey, address *Address) (*WatchOnly, error) {
+	derived, err := viewKeyAddress(viewKey)
+	if err != nil {
+		return nil, fmt.Errorf("NewWatchOnly : %w", err)
+	}
+
+	if derived.String() != address.String() {
+		return nil, fmt.Errorf("NewWatchOnly : %w : got %s want %s", errViewKeyMismatch, derived, address)
+	}
+
+	return &WatchOnly{viewKey: viewKey.Copy(), address: address.Copy()}, nil
+}
+
+// WatchOnly returns the Account without its private key.
+func (a *Account) WatchOnly() *WatchOnly {
+	return &WatchOnly{viewKey: a.ViewKey(), address: a.Address()}
+}
+
+// ViewKey returns a copy of the WatchOnly's ViewKey.
+func (w *WatchOnly) ViewKey() *ViewKey {
+	return w.viewKey.Copy()
+}
+
+// Address returns a copy of the WatchOnly's Address.
+func (w *WatchOnly) Address() *Address {
+	return w.address.Copy()
+}
+
+// MarshalJSON implements the marshaller interface.
+func (w *WatchOnly) MarshalJSON() ([]byte, error) {
+	return json.Marshal(WatchOnlyJSON{
+		ViewKey: w.viewKey.String(),
+		Address: w.address.String(),
+	})
+}
+
+// UnmarshalJSON implements the marshaller interface.
+// Like Account, the keys are parsed but not checked against each other; use NewWatchOnly for that.
+func (w *WatchOnly) UnmarshalJSON(b []byte) error {
+	temp := &WatchOnlyJSON{}
+
+	if err := json.Unmarshal(b, &temp); err != nil {
+		return err
+	}
+
+	address, err := ParseAddress(temp.Address)
+	if err != nil {
+		return err
+	}
+
+	viewKey, err := ParseViewKey(temp.ViewKey)
+	if err != nil {
+		return err
+	}
+
+	w.viewKey = viewKey
+	w.address = address
+
+	return nil
+}
@@ -0,0 +1,63 @@
+package account
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestNewWatchOnly(t *testing.T) {
+	vk, err := ParseViewKey("AViewKey1iAf6a7fv6ELA4ECwAth1hDNUJJNNoWNThmREjpybqder")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	addr, err := ParseAddress("aleo1d5hg2z3ma00382pngntdp68e74zv54jdxy249qhaujhks9c72yrs33ddah")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w, err := NewWatchOnly(vk, addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := w.Address().String(); got != addr.String() {
+		t.Fatalf("got %s want %s", got, addr)
+	}
+
+	other, err := ParseViewKey("AViewKey1m8gvywHKHKfUzZiLiLoHedcdHEjKwo5TWo6efz8gK7wF")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := NewWatchOnly(other, addr); !errors.Is(err, errViewKeyMismatch) {
+		t.Fatalf("got %v want %v", err, errViewKeyMismatch)
+	}
+}
+
+func TestWatchOnlyJSON(t *testing.T) {
+	acc := testAccount(t)
+
+	buf, err := json.Marshal(acc.WatchOnly())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Contains(string(buf), "privatekey") || strings.Contains(string(buf), acc.PrivateKey().String()) {
+		t.Fatalf("private key in %s", buf)
+	}
+
+	var w WatchOnly
+	if err := json.Unmarshal(buf, &w); err != nil {
+		t.Fatal(err)
+	}
+
+	if w.ViewKey().String() != acc.ViewKey().String() || w.Address().String() != acc.Address().String() {
+		t.Fatalf("got %s %s", w.ViewKey(), w.Address())
+	}
+
+	var _ Viewer = acc
+	var _ Viewer = &w
+}
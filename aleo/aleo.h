@@ -19,6 +19,7 @@ extern account_t * from_seed(const uint8_t *n, size_t len);
 char * account_private_key(const account_t *);
 char * account_view_key(const account_t *);
 char * account_address(const account_t *);
+char * view_key_address(const char *view_key);
 void * account_free(account_t *ptr);
 
 /* record */
@@ -49,3 +50,4 @@ char *new_transfer_transaction(const char *in_record,
                                int64_t fee,
                                const char *address);
 
+char *transaction_json(const char *txn);
@@ -1,6 +1,6 @@
 use crate::c_error;
 use rand::{rngs::StdRng, SeedableRng};
-use snarkvm_dpc::{network::testnet2::Testnet2, Account, AccountScheme, PrivateKey};
+use snarkvm_dpc::{network::testnet2::Testnet2, Account, AccountScheme, Address, PrivateKey, ViewKey};
 use std::ffi::{CStr, CString};
 use std::str::FromStr;
 
@@ -71,6 +71,26 @@ pub extern "C" fn account_address(ptr: *mut Account<Testnet2>) -> *mut libc::c_c
     CString::new(address).unwrap().into_raw()
 }
 
+#[no_mangle]
+pub extern "C" fn view_key_address(view_key: *const libc::c_char) -> *mut libc::c_char {
+    let c_view_key = unsafe {
+        assert!(!view_key.is_null());
+
+        CStr::from_ptr(view_key)
+    };
+
+    let view_key = match ViewKey::<Testnet2>::from_str(c_view_key.to_str().unwrap()) {
+        Ok(key) => key,
+        Err(error) => {
+            c_error::update_last_error(error);
+            return std::ptr::null_mut();
+        }
+    };
+
+    let address = Address::<Testnet2>::from_view_key(&view_key).to_string();
+    CString::new(address).unwrap().into_raw()
+}
+
 #[no_mangle]
 pub extern "C" fn account_free(ptr: *mut Account<Testnet2>) {
     if ptr.is_null() {
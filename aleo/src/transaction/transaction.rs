@@ -150,3 +150,39 @@ pub extern "C" fn new_transfer_transaction(
 
     CString::new(serialized_tx.to_string()).unwrap().into_raw()
 }
+
+#[no_mangle]
+pub extern "C" fn transaction_json(txn: *const libc::c_char) -> *mut libc::c_char {
+    let c_txn = unsafe {
+        assert!(!txn.is_null());
+
+        CStr::from_ptr(txn)
+    };
+
+    let tx_bytes = match hex::decode(c_txn.to_str().unwrap()) {
+        Ok(res) => res,
+        Err(_) => {
+            c_error::update_last_error(snarkvm_utilities::error("transaction is not valid hex"));
+            return std::ptr::null_mut();
+        }
+    };
+
+    // Deserializing recomputes the transaction, transition and ciphertext IDs.
+    let transaction = match Transaction::<Testnet2>::from_bytes_le(&tx_bytes) {
+        Ok(res) => res,
+        Err(_) => {
+            c_error::update_last_error(snarkvm_utilities::error("could not read transaction"));
+            return std::ptr::null_mut();
+        }
+    };
+
+    let json = match serde_json::to_string(&transaction) {
+        Ok(res) => res,
+        Err(_) => {
+            c_error::update_last_error(snarkvm_utilities::error("could not serialize transaction"));
+            return std::ptr::null_mut();
+        }
+    };
+
+    CString::new(json).unwrap().into_raw()
+}
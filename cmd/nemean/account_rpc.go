@@ -2,7 +2,10 @@ package main
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"github.com/pinestreetlabs/aleo-wallet-sdk/ids"
+	"github.com/pinestreetlabs/aleo-wallet-sdk/rpc"
 	"github.com/urfave/cli"
 )
 
@@ -12,12 +15,25 @@ func getBlock(ctx *cli.Context) error {
 		return err
 	}
 
-	client, err := getClient(profile.host, profile.port)
+	client, err := getChainClient(profile)
 	if err != nil {
 		return err
 	}
 
-	resp, err := client.GetBlock(ctx.Int64("height"))
+	reqCtx, cancel := getContext(ctx)
+	defer cancel()
+
+	var resp *rpc.Block
+	switch {
+	case ctx.IsSet("hash") && ctx.IsSet("height"):
+		return errors.New("only one of height and hash may be set")
+	case ctx.IsSet("hash"):
+		resp, err = client.GetBlockByHash(reqCtx, *ctx.Generic("hash").(*ids.BlockHash))
+	case ctx.IsSet("height"):
+		resp, err = client.GetBlock(reqCtx, ctx.Int64("height"))
+	default:
+		return errors.New("height or hash is required")
+	}
 	if err != nil {
 		return err
 	}
@@ -38,12 +54,15 @@ func getBlockHash(ctx *cli.Context) error {
 		return err
 	}
 
-	client, err := getClient(profile.host, profile.port)
+	client, err := getChainClient(profile)
 	if err != nil {
 		return err
 	}
 
-	resp, err := client.GetBlockHash(ctx.Int64("height"))
+	reqCtx, cancel := getContext(ctx)
+	defer cancel()
+
+	resp, err := client.GetBlockHash(reqCtx, ctx.Int64("height"))
 	if err != nil {
 		return err
 	}
@@ -59,12 +78,15 @@ func getBlockHashes(ctx *cli.Context) error {
 		return err
 	}
 
-	client, err := getClient(profile.host, profile.port)
+	client, err := getClient(profile)
 	if err != nil {
 		return err
 	}
 
-	resp, err := client.GetBlockHashes(ctx.Int64("start"), ctx.Int64("end"))
+	reqCtx, cancel := getContext(ctx)
+	defer cancel()
+
+	resp, err := client.GetBlockHashes(reqCtx, ctx.Int64("start"), ctx.Int64("end"))
 	if err != nil {
 		return err
 	}
@@ -80,12 +102,15 @@ func getBlockHeader(ctx *cli.Context) error {
 		return err
 	}
 
-	client, err := getClient(profile.host, profile.port)
+	client, err := getChainClient(profile)
 	if err != nil {
 		return err
 	}
 
-	resp, err := client.GetBlockHeader(ctx.Int64("height"))
+	reqCtx, cancel := getContext(ctx)
+	defer cancel()
+
+	resp, err := client.GetBlockHeader(reqCtx, ctx.Int64("height"))
 	if err != nil {
 		return err
 	}
@@ -106,12 +131,15 @@ func getTransaction(ctx *cli.Context) error {
 		return err
 	}
 
-	client, err := getClient(profile.host, profile.port)
+	client, err := getChainClient(profile)
 	if err != nil {
 		return err
 	}
 
-	resp, err := client.GetTransaction(ctx.String("id"))
+	reqCtx, cancel := getContext(ctx)
+	defer cancel()
+
+	resp, err := client.GetTransaction(reqCtx, *ctx.Generic("id").(*ids.TransactionID))
 	if err != nil {
 		return err
 	}
@@ -132,12 +160,15 @@ func getTransition(ctx *cli.Context) error {
 		return err
 	}
 
-	client, err := getClient(profile.host, profile.port)
+	client, err := getChainClient(profile)
 	if err != nil {
 		return err
 	}
 
-	resp, err := client.GetTransition(ctx.String("id"))
+	reqCtx, cancel := getContext(ctx)
+	defer cancel()
+
+	resp, err := client.GetTransition(reqCtx, *ctx.Generic("id").(*ids.TransitionID))
 	if err != nil {
 		return err
 	}
@@ -158,12 +189,15 @@ func getBlockHeight(ctx *cli.Context) error {
 		return err
 	}
 
-	client, err := getClient(profile.host, profile.port)
+	client, err := getChainClient(profile)
 	if err != nil {
 		return err
 	}
 
-	resp, err := client.GetBlockHeight(ctx.String("hash"))
+	reqCtx, cancel := getContext(ctx)
+	defer cancel()
+
+	resp, err := client.GetBlockHeight(reqCtx, *ctx.Generic("hash").(*ids.BlockHash))
 	if err != nil {
 		return err
 	}
@@ -179,12 +213,15 @@ func getBlocks(ctx *cli.Context) error {
 		return err
 	}
 
-	client, err := getClient(profile.host, profile.port)
+	client, err := getChainClient(profile)
 	if err != nil {
 		return err
 	}
 
-	resp, err := client.GetBlocks(ctx.Int64("start"), ctx.Int64("end"))
+	reqCtx, cancel := getContext(ctx)
+	defer cancel()
+
+	resp, err := client.GetBlocks(reqCtx, ctx.Int64("start"), ctx.Int64("end"))
 	if err != nil {
 		return err
 	}
@@ -205,12 +242,15 @@ func getBlockTransactions(ctx *cli.Context) error {
 		return err
 	}
 
-	client, err := getClient(profile.host, profile.port)
+	client, err := getChainClient(profile)
 	if err != nil {
 		return err
 	}
 
-	resp, err := client.GetBlockTransactions(ctx.Int64("height"))
+	reqCtx, cancel := getContext(ctx)
+	defer cancel()
+
+	resp, err := client.GetBlockTransactions(reqCtx, ctx.Int64("height"))
 	if err != nil {
 		return err
 	}
@@ -231,12 +271,15 @@ func getCiphertext(ctx *cli.Context) error {
 		return err
 	}
 
-	client, err := getClient(profile.host, profile.port)
+	client, err := getClient(profile)
 	if err != nil {
 		return err
 	}
 
-	resp, err := client.GetCiphertext(ctx.String("id"))
+	reqCtx, cancel := getContext(ctx)
+	defer cancel()
+
+	resp, err := client.GetCiphertext(reqCtx, *ctx.Generic("id").(*ids.CiphertextID))
 	if err != nil {
 		return err
 	}
@@ -252,12 +295,15 @@ func latestBlockHeight(ctx *cli.Context) error {
 		return err
 	}
 
-	client, err := getClient(profile.host, profile.port)
+	client, err := getChainClient(profile)
 	if err != nil {
 		return err
 	}
 
-	resp, err := client.LatestBlockHeight()
+	reqCtx, cancel := getContext(ctx)
+	defer cancel()
+
+	resp, err := client.LatestBlockHeight(reqCtx)
 	if err != nil {
 		return err
 	}
@@ -273,12 +319,15 @@ func latestBlock(ctx *cli.Context) error {
 		return err
 	}
 
-	client, err := getClient(profile.host, profile.port)
+	client, err := getChainClient(profile)
 	if err != nil {
 		return err
 	}
 
-	resp, err := client.LatestBlock()
+	reqCtx, cancel := getContext(ctx)
+	defer cancel()
+
+	resp, err := client.LatestBlock(reqCtx)
 	if err != nil {
 		return err
 	}
@@ -299,12 +348,15 @@ func latestBlockHeader(ctx *cli.Context) error {
 		return err
 	}
 
-	client, err := getClient(profile.host, profile.port)
+	client, err := getChainClient(profile)
 	if err != nil {
 		return err
 	}
 
-	resp, err := client.LatestBlockHeader()
+	reqCtx, cancel := getContext(ctx)
+	defer cancel()
+
+	resp, err := client.LatestBlockHeader(reqCtx)
 	if err != nil {
 		return err
 	}
@@ -325,12 +377,15 @@ func latestBlockTransactions(ctx *cli.Context) error {
 		return err
 	}
 
-	client, err := getClient(profile.host, profile.port)
+	client, err := getChainClient(profile)
 	if err != nil {
 		return err
 	}
 
-	resp, err := client.LatestBlockTransactions()
+	reqCtx, cancel := getContext(ctx)
+	defer cancel()
+
+	resp, err := client.LatestBlockTransactions(reqCtx)
 	if err != nil {
 		return err
 	}
@@ -351,12 +406,15 @@ func sendTransaction(ctx *cli.Context) error {
 		return err
 	}
 
-	client, err := getClient(profile.host, profile.port)
+	client, err := getChainClient(profile)
 	if err != nil {
 		return err
 	}
 
-	resp, err := client.SendTransaction(ctx.String("txn"))
+	reqCtx, cancel := getContext(ctx)
+	defer cancel()
+
+	resp, err := client.SendTransaction(reqCtx, ctx.String("txn"))
 	if err != nil {
 		return err
 	}
@@ -377,12 +435,15 @@ func latestLedgerRoot(ctx *cli.Context) error {
 		return err
 	}
 
-	client, err := getClient(profile.host, profile.port)
+	client, err := getClient(profile)
 	if err != nil {
 		return err
 	}
 
-	resp, err := client.LatestLedgerRoot()
+	reqCtx, cancel := getContext(ctx)
+	defer cancel()
+
+	resp, err := client.LatestLedgerRoot(reqCtx)
 	if err != nil {
 		return err
 	}
@@ -398,12 +459,239 @@ func getLedgerProof(ctx *cli.Context) error {
 		return err
 	}
 
-	client, err := getClient(profile.host, profile.port)
+	client, err := getClient(profile)
+	if err != nil {
+		return err
+	}
+
+	reqCtx, cancel := getContext(ctx)
+	defer cancel()
+
+	resp, err := client.GetLedgerProof(reqCtx, *ctx.Generic("commitment").(*ids.Commitment))
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", resp)
+
+	return nil
+}
+
+func getBestBlockHash(ctx *cli.Context) error {
+	profile, err := getProfile(ctx)
+	if err != nil {
+		return err
+	}
+
+	client, err := getClient(profile)
+	if err != nil {
+		return err
+	}
+
+	reqCtx, cancel := getContext(ctx)
+	defer cancel()
+
+	resp, err := client.GetBestBlockHash(reqCtx)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", resp)
+
+	return nil
+}
+
+func latestBlockHash(ctx *cli.Context) error {
+	profile, err := getProfile(ctx)
+	if err != nil {
+		return err
+	}
+
+	client, err := getChainClient(profile)
+	if err != nil {
+		return err
+	}
+
+	reqCtx, cancel := getContext(ctx)
+	defer cancel()
+
+	resp, err := client.LatestBlockHash(reqCtx)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", resp)
+
+	return nil
+}
+
+func latestCumulativeWeight(ctx *cli.Context) error {
+	profile, err := getProfile(ctx)
+	if err != nil {
+		return err
+	}
+
+	client, err := getClient(profile)
+	if err != nil {
+		return err
+	}
+
+	reqCtx, cancel := getContext(ctx)
+	defer cancel()
+
+	resp, err := client.LatestCumulativeWeight(reqCtx)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", resp)
+
+	return nil
+}
+
+func getConnectedPeers(ctx *cli.Context) error {
+	profile, err := getProfile(ctx)
+	if err != nil {
+		return err
+	}
+
+	client, err := getChainClient(profile)
+	if err != nil {
+		return err
+	}
+
+	reqCtx, cancel := getContext(ctx)
+	defer cancel()
+
+	resp, err := client.GetConnectedPeers(reqCtx)
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(resp)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", body)
+
+	return nil
+}
+
+func getNodeState(ctx *cli.Context) error {
+	profile, err := getProfile(ctx)
+	if err != nil {
+		return err
+	}
+
+	client, err := getClient(profile)
+	if err != nil {
+		return err
+	}
+
+	reqCtx, cancel := getContext(ctx)
+	defer cancel()
+
+	resp, err := client.GetNodeState(reqCtx)
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(resp)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", body)
+
+	return nil
+}
+
+func getMemoryPool(ctx *cli.Context) error {
+	profile, err := getProfile(ctx)
+	if err != nil {
+		return err
+	}
+
+	client, err := getChainClient(profile)
+	if err != nil {
+		return err
+	}
+
+	reqCtx, cancel := getContext(ctx)
+	defer cancel()
+
+	resp, err := client.GetMemoryPool(reqCtx)
 	if err != nil {
 		return err
 	}
 
-	resp, err := client.GetLedgerProof(ctx.String("commitment"))
+	body, err := json.Marshal(resp)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", body)
+
+	return nil
+}
+
+func getBlockTemplate(ctx *cli.Context) error {
+	profile, err := getProfile(ctx)
+	if err != nil {
+		return err
+	}
+
+	client, err := getClient(profile)
+	if err != nil {
+		return err
+	}
+
+	reqCtx, cancel := getContext(ctx)
+	defer cancel()
+
+	resp, err := client.GetBlockTemplate(reqCtx)
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(resp)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", body)
+
+	return nil
+}
+
+func rawRPC(ctx *cli.Context) error {
+	if !ctx.Args().Present() {
+		return errors.New("method is required")
+	}
+
+	var params []interface{}
+	for _, arg := range ctx.Args().Tail() {
+		if !json.Valid([]byte(arg)) {
+			return fmt.Errorf("param %s is not a JSON literal, strings must be quoted", arg)
+		}
+		params = append(params, json.RawMessage(arg))
+	}
+
+	profile, err := getProfile(ctx)
+	if err != nil {
+		return err
+	}
+
+	client, err := getClient(profile)
+	if err != nil {
+		return err
+	}
+
+	reqCtx, cancel := getContext(ctx)
+	defer cancel()
+
+	resp, err := client.Call(reqCtx, ctx.Args().First(), params...)
 	if err != nil {
 		return err
 	}
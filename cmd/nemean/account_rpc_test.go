@@ -0,0 +1,342 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/pinestreetlabs/aleo-wallet-sdk/ids"
+	"github.com/pinestreetlabs/aleo-wallet-sdk/rpc"
+	"github.com/pinestreetlabs/aleo-wallet-sdk/rpc/rpctest"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+// run executes the CLI with args and returns what it printed to stdout.
+func run(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+
+	out := make(chan string)
+	go func() {
+		var buf bytes.Buffer
+		io.Copy(&buf, r)
+		out <- buf.String()
+	}()
+
+	runErr := newApp().Run(append([]string{"nemean"}, args...))
+
+	w.Close()
+	return strings.TrimSpace(<-out), runErr
+}
+
+func newTestServer(t *testing.T) (*rpctest.Server, rpc.Transaction) {
+	t.Helper()
+
+	s := rpctest.NewServer()
+	t.Cleanup(s.Close)
+
+	tx := rpc.Transaction{
+		TxID: ids.TransactionID(rpctest.NewID("at", "tx")),
+		Transitions: []rpc.Transition{{
+			ID:            ids.TransitionID(rpctest.NewID("as", "transition")),
+			CiphertextIDs: []ids.CiphertextID{ids.CiphertextID(rpctest.NewID("ar", "ciphertext"))},
+			Ciphertexts:   []string{"deadbeef"},
+			Commitments:   []ids.Commitment{ids.Commitment(rpctest.NewID("cm", "commitment"))},
+		}},
+	}
+
+	s.AddBlocks(2)
+	s.AddBlock(rpc.Block{Transactions: rpc.Transactions{Transactions: []rpc.Transaction{tx}}})
+	s.AddLedgerProof(ids.Commitment(rpctest.NewID("cm", "commitment")), "proof")
+	s.AddMemoryPoolTransaction(rpc.Transaction{TxID: ids.TransactionID(rpctest.NewID("at", "pending"))})
+	s.SetPeers([]string{"127.0.0.1:4132"})
+
+	return s, tx
+}
+
+func TestRPCCommands(t *testing.T) {
+	s, tx := newTestServer(t)
+	rpcFlag := "--rpc=" + s.Addr()
+
+	client, err := rpc.NewClient(s.Config())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tip, err := client.LatestBlock(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		args   []string
+		method string
+		check  func(t *testing.T, out string)
+	}{
+		{
+			args:   []string{"latestblockheight"},
+			method: "latestblockheight",
+			check:  equals("2"),
+		},
+		{
+			args:   []string{"getblockhash", "--height=1"},
+			method: "getblockhash",
+			check:  hasPrefix("ab1"),
+		},
+		{
+			args:   []string{"getblockhashes", "--start=0", "--end=2"},
+			method: "getblockhashes",
+			check:  hasPrefix("[ab1"),
+		},
+		{
+			args:   []string{"getblock", "--height=2"},
+			method: "getblock",
+			check:  decodes(&rpc.Block{}),
+		},
+		{
+			args:   []string{"getblocks", "--start=0", "--end=2"},
+			method: "getblocks",
+			check:  decodes(&[]rpc.Block{}),
+		},
+		{
+			args:   []string{"getblockheader", "--height=2"},
+			method: "getblockheader",
+			check:  decodes(&rpc.BlockHeader{}),
+		},
+		{
+			args:   []string{"getblocktransactions", "--height=2"},
+			method: "getblocktransactions",
+			check:  contains(tx.TxID.String()),
+		},
+		{
+			args:   []string{"gettransaction", "--id=" + tx.TxID.String()},
+			method: "gettransaction",
+			check:  contains(`"block_height":2`),
+		},
+		{
+			args:   []string{"gettransition", "--id=" + tx.Transitions[0].ID.String()},
+			method: "gettransition",
+			check:  contains(tx.Transitions[0].ID.String()),
+		},
+		{
+			args:   []string{"getciphertext", "--id=" + tx.Transitions[0].CiphertextIDs[0].String()},
+			method: "getciphertext",
+			check:  equals("deadbeef"),
+		},
+		{
+			args:   []string{"getledgerproof", "--commitment=" + tx.Transitions[0].Commitments[0].String()},
+			method: "getledgerproof",
+			check:  equals("proof"),
+		},
+		{
+			args:   []string{"latestblock"},
+			method: "latestblock",
+			check:  contains(tx.TxID.String()),
+		},
+		{
+			args:   []string{"latestblockheader"},
+			method: "latestblockheader",
+			check:  contains(`"height":2`),
+		},
+		{
+			args:   []string{"latestblocktransactions"},
+			method: "latestblocktransactions",
+			check:  contains(tx.TxID.String()),
+		},
+		{
+			args:   []string{"latestledgerroot"},
+			method: "latestledgerroot",
+			check:  hasPrefix("al1"),
+		},
+		{
+			args:   []string{"getblock", "--hash=" + tip.BlockHash.String()},
+			method: "getblockheight",
+			check:  contains(tx.TxID.String()),
+		},
+		{
+			args:   []string{"getbestblockhash"},
+			method: "getbestblockhash",
+			check:  hasPrefix("ab1"),
+		},
+		{
+			args:   []string{"latestblockhash"},
+			method: "latestblockhash",
+			check:  hasPrefix("ab1"),
+		},
+		{
+			args:   []string{"latestcumulativeweight"},
+			method: "latestcumulativeweight",
+			check:  equals("3"),
+		},
+		{
+			args:   []string{"getconnectedpeers"},
+			method: "getconnectedpeers",
+			check:  equals(`["127.0.0.1:4132"]`),
+		},
+		{
+			args:   []string{"getnodestate"},
+			method: "getnodestate",
+			check:  contains(`"latest_block_height":2`),
+		},
+		{
+			args:   []string{"getmemorypool"},
+			method: "getmemorypool",
+			check:  contains(rpctest.NewID("at", "pending")),
+		},
+		{
+			args:   []string{"getblocktemplate"},
+			method: "getblocktemplate",
+			check:  contains(`"block_height":3`),
+		},
+		{
+			args:   []string{"rpc", "getblockheight", `"` + tip.BlockHash.String() + `"`},
+			method: "getblockheight",
+			check:  equals("2"),
+		},
+		{
+			args:   []string{"rpc", "getblockhashes", "0", "1"},
+			method: "getblockhashes",
+			check:  hasPrefix(`["ab1`),
+		},
+		{
+			args:   []string{"sendtransaction", "--txn=deadbeef"},
+			method: "sendtransaction",
+			check:  equals(`"` + rpctest.NewID("at", "deadbeef") + `"`),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.args[0], func(t *testing.T) {
+			before := s.CallCount(tc.method)
+
+			out, err := run(t, append([]string{rpcFlag}, tc.args...)...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if s.CallCount(tc.method) != before+1 {
+				t.Fatalf("expected a %s call", tc.method)
+			}
+
+			tc.check(t, out)
+		})
+	}
+}
+
+func TestRPCCommandErrors(t *testing.T) {
+	s, _ := newTestServer(t)
+
+	if _, err := run(t, "--rpc="+s.Addr(), "gettransaction", "--id="+rpctest.NewID("at", "missing")); err == nil {
+		t.Fatal("expected err")
+	}
+
+	if _, err := run(t, "--rpc=invalid", "latestblockheight"); err == nil {
+		t.Fatal("expected err")
+	}
+
+	if _, err := run(t, "--rpc="+s.Addr(), "rpc"); err == nil {
+		t.Fatal("expected err")
+	}
+
+	if _, err := run(t, "--rpc="+s.Addr(), "rpc", "getblockheight", "ab1unquoted"); err == nil {
+		t.Fatal("expected err")
+	}
+
+	if _, err := run(t, "--rpc="+s.Addr(), "rpc", "getfuturething"); !errors.Is(err, rpc.ErrMethodNotFound) {
+		t.Fatalf("got %v want %v", err, rpc.ErrMethodNotFound)
+	}
+
+	if _, err := run(t, "--rpc="+s.Addr(), "getblock"); err == nil {
+		t.Fatal("expected err")
+	}
+
+	if _, err := run(t, "--rpc="+s.Addr(), "--rpc_header=invalid", "latestblockheight"); err == nil {
+		t.Fatal("expected err")
+	}
+
+	s.SetError("latestblockheight", &rpc.Error{Code: -32603, Message: "Internal error"})
+	if _, err := run(t, "--rpc="+s.Addr(), "latestblockheight"); err == nil {
+		t.Fatal("expected err")
+	}
+}
+
+func TestRPCURL(t *testing.T) {
+	s, _ := newTestServer(t)
+
+	out, err := run(t, "--rpc="+s.URL(), "--rpc_header=X-Api-Key: key", "latestblockheight")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	equals("2")(t, out)
+}
+
+func TestRESTAPI(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/mainnet/latest/height" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`5`))
+	}))
+	defer srv.Close()
+
+	out, err := run(t, "--rpc="+srv.URL, "--api=rest", "--network=mainnet", "latestblockheight")
+	if err != nil {
+		t.Fatal(err)
+	}
+	equals("5")(t, out)
+
+	if _, err := run(t, "--rpc="+srv.URL, "--api=rest", "latestledgerroot"); err == nil {
+		t.Fatal("expected err")
+	}
+
+	if _, err := run(t, "--rpc="+srv.URL, "--api=grpc", "latestblockheight"); err == nil {
+		t.Fatal("expected err")
+	}
+}
+
+func equals(want string) func(t *testing.T, out string) {
+	return func(t *testing.T, out string) {
+		if out != want {
+			t.Fatalf("got %s want %s", out, want)
+		}
+	}
+}
+
+func hasPrefix(want string) func(t *testing.T, out string) {
+	return func(t *testing.T, out string) {
+		if !strings.HasPrefix(out, want) {
+			t.Fatalf("got %s want prefix %s", out, want)
+		}
+	}
+}
+
+func contains(want string) func(t *testing.T, out string) {
+	return func(t *testing.T, out string) {
+		if !strings.Contains(out, want) {
+			t.Fatalf("got %s want to contain %s", out, want)
+		}
+	}
+}
+
+func decodes(v interface{}) func(t *testing.T, out string) {
+	return func(t *testing.T, out string) {
+		if err := json.Unmarshal([]byte(out), v); err != nil {
+			t.Fatalf("%v : %s", err, out)
+		}
+	}
+}
@@ -11,38 +11,217 @@ import (
 	"github.com/pinestreetlabs/aleo-wallet-sdk/record"
 	"github.com/pinestreetlabs/aleo-wallet-sdk/transaction"
 	"github.com/urfave/cli"
+	"io/ioutil"
+	"strings"
 )
 
 var errInvalidSeed = errors.New("invalid seed")
+var errMnemonicMismatch = errors.New("mnemonic does not match")
+var errMissingKey = errors.New("a private key or keystore is required")
+var errBackupRequired = errors.New("--hd and --mnemonic with --keystore print the seed, set --backup to confirm")
+
+// seedAccount is printed by create --hd, create --mnemonic, create --keystore, combine --keystore, derive and restore.
+// With a keystore, the account is replaced by its address; create only prints the seed or mnemonic with --backup.
+type seedAccount struct {
+	Seed     string           `json:"seed,omitempty"`
+	Mnemonic string           `json:"mnemonic,omitempty"`
+	Path     string           `json:"path,omitempty"`
+	Keystore string           `json:"keystore,omitempty"`
+	Address  string           `json:"address,omitempty"`
+	Account  *account.Account `json:"account,omitempty"`
+}
 
 func newAccount(ctx *cli.Context) (err error) {
 	var seed [32]byte
-	if ctx.NumFlags() == 1 {
-		in := ctx.String("from")
+	if ctx.IsSet("from") {
+		seed, err = parseSeed(ctx.String("from"))
+	} else {
+		seed, err = account.NewSeed()
+	}
+	if err != nil {
+		return err
+	}
+
+	// The seed or mnemonic is a plaintext copy of the keystore, so printing it must be asked for.
+	if ctx.IsSet("keystore") && (ctx.Bool("hd") || ctx.Bool("mnemonic")) && !ctx.Bool("backup") {
+		return errBackupRequired
+	}
+
+	acc, err := seedToAccount(ctx, seed)
+	if err != nil {
+		return err
+	}
 
-		buf, err := base64.StdEncoding.DecodeString(in)
+	if !ctx.Bool("mnemonic") && !ctx.Bool("hd") && !ctx.IsSet("keystore") {
+		resp, err := json.Marshal(acc)
 		if err != nil {
-			return fmt.Errorf("%w : %v", errInvalidSeed, err)
+			return err
 		}
 
-		if len(buf) != 32 {
-			return fmt.Errorf("%w : got len %d", errInvalidSeed, len(buf))
+		fmt.Printf("%s\n", resp)
+		return nil
+	}
+
+	out := seedAccount{Path: accountPath(ctx), Account: acc}
+	if ctx.Bool("mnemonic") {
+		out.Mnemonic = account.NewMnemonic(seed, ctx.String("passphrase"))
+	} else if ctx.Bool("hd") {
+		out.Seed = base64.StdEncoding.EncodeToString(seed[:])
+	}
+
+	if ctx.IsSet("keystore") {
+		if err := saveKeystore(ctx, acc); err != nil {
+			return err
 		}
 
-		copy(seed[:], buf)
-	} else {
-		seed, err = account.NewSeed()
+		out.Keystore = ctx.String("keystore")
+		out.Address = acc.Address().String()
+		out.Account = nil
+	}
+
+	resp, err := json.Marshal(out)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", resp)
+	return nil
+}
+
+func deriveAccount(ctx *cli.Context) error {
+	master, err := parseSeed(ctx.String("from"))
+	if err != nil {
+		return err
+	}
+
+	acc, err := account.DeriveAccount(master, ctx.String("path"), network.Testnet2())
+	if err != nil {
+		return err
+	}
+
+	resp, err := json.Marshal(seedAccount{Path: ctx.String("path"), Account: acc})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", resp)
+	return nil
+}
+
+func restoreAccount(ctx *cli.Context) error {
+	seed, err := account.SeedFromMnemonic(ctx.String("mnemonic"), ctx.String("passphrase"))
+	if err != nil {
+		return err
+	}
+
+	acc, err := seedToAccount(ctx, seed)
+	if err != nil {
+		return err
+	}
+
+	resp, err := json.Marshal(seedAccount{
+		Seed:    base64.StdEncoding.EncodeToString(seed[:]),
+		Path:    accountPath(ctx),
+		Account: acc,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", resp)
+	return nil
+}
+
+func checkMnemonic(ctx *cli.Context) error {
+	mnemonic := strings.Join(strings.Fields(strings.ToLower(ctx.String("mnemonic"))), " ")
+
+	seed, err := account.SeedFromMnemonic(mnemonic, ctx.String("passphrase"))
+	if err != nil {
+		return err
+	}
+
+	if got := account.NewMnemonic(seed, ctx.String("passphrase")); got != mnemonic {
+		return fmt.Errorf("%w : re-encoded as %s", errMnemonicMismatch, got)
+	}
+
+	if ctx.IsSet("from") {
+		want, err := parseSeed(ctx.String("from"))
 		if err != nil {
 			return err
 		}
+		if seed != want {
+			return fmt.Errorf("%w : seed differs, check the passphrase", errMnemonicMismatch)
+		}
 	}
 
-	acc, err := account.FromSeed(seed, network.Testnet2())
+	acc, err := seedToAccount(ctx, seed)
 	if err != nil {
 		return err
 	}
 
-	resp, err := json.Marshal(acc)
+	if ctx.IsSet("address") && acc.Address().String() != ctx.String("address") {
+		return fmt.Errorf("%w : restores %s, check the passphrase", errMnemonicMismatch, acc.Address())
+	}
+
+	fmt.Println(acc.Address())
+	return nil
+}
+
+// seedToAccount returns the account of seed, derived along --path when --hd is set.
+func seedToAccount(ctx *cli.Context, seed [32]byte) (*account.Account, error) {
+	if ctx.Bool("hd") {
+		return account.DeriveAccount(seed, ctx.String("path"), network.Testnet2())
+	}
+
+	return account.FromSeed(seed, network.Testnet2())
+}
+
+// accountPath returns --path when the account is derived with --hd.
+func accountPath(ctx *cli.Context) string {
+	if ctx.Bool("hd") {
+		return ctx.String("path")
+	}
+
+	return ""
+}
+
+// parseSeed decodes a base64 encoded 32 byte seed.
+func parseSeed(in string) ([32]byte, error) {
+	var seed [32]byte
+
+	buf, err := base64.StdEncoding.DecodeString(in)
+	if err != nil {
+		return seed, fmt.Errorf("%w : %v", errInvalidSeed, err)
+	}
+
+	if len(buf) != 32 {
+		return seed, fmt.Errorf("%w : got len %d", errInvalidSeed, len(buf))
+	}
+
+	copy(seed[:], buf)
+	return seed, nil
+}
+
+func fromAccount(ctx *cli.Context) (err error) {
+	var acc *account.Account
+	switch {
+	case ctx.IsSet("keystore"):
+		acc, err = loadKeystore(ctx)
+	case ctx.IsSet("from"):
+		acc, err = account.FromPrivateKey(ctx.String("from"), network.Testnet2())
+	default:
+		err = errMissingKey
+	}
+	if err != nil {
+		return err
+	}
+
+	var resp []byte
+	if ctx.Bool("watch_only") {
+		resp, err = json.Marshal(acc.WatchOnly())
+	} else {
+		resp, err = json.Marshal(acc)
+	}
 	if err != nil {
 		return err
 	}
@@ -51,15 +230,78 @@ func newAccount(ctx *cli.Context) (err error) {
 	return nil
 }
 
-func fromAccount(ctx *cli.Context) error {
-	key := ctx.String("from")
+func watchAccount(ctx *cli.Context) error {
+	vk, err := account.ParseViewKey(ctx.String("viewkey"))
+	if err != nil {
+		return err
+	}
+
+	addr, err := account.ParseAddress(ctx.String("address"))
+	if err != nil {
+		return err
+	}
 
-	acc, err := account.FromPrivateKey(key, network.Testnet2())
+	w, err := account.NewWatchOnly(vk, addr)
 	if err != nil {
 		return err
 	}
 
-	resp, err := json.Marshal(acc)
+	resp, err := json.Marshal(w)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", resp)
+	return nil
+}
+
+func splitKey(ctx *cli.Context) error {
+	sk, err := readPrivateKey(ctx)
+	if err != nil {
+		return err
+	}
+
+	shares, err := account.SplitSeed(sk.Seed, ctx.Int("threshold"), ctx.Int("shares"))
+	if err != nil {
+		return err
+	}
+
+	for _, share := range shares {
+		fmt.Println(share)
+	}
+	return nil
+}
+
+func combineShares(ctx *cli.Context) error {
+	var shares []account.Share
+	for _, s := range ctx.StringSlice("share") {
+		share, err := account.ParseShare(s)
+		if err != nil {
+			return err
+		}
+		shares = append(shares, *share)
+	}
+
+	seed, err := account.CombineShares(shares)
+	if err != nil {
+		return err
+	}
+
+	acc, err := account.FromSeed(seed, network.Testnet2())
+	if err != nil {
+		return err
+	}
+
+	var resp []byte
+	if ctx.IsSet("keystore") {
+		if err := saveKeystore(ctx, acc); err != nil {
+			return err
+		}
+
+		resp, err = json.Marshal(seedAccount{Keystore: ctx.String("keystore"), Address: acc.Address().String()})
+	} else {
+		resp, err = json.Marshal(acc)
+	}
 	if err != nil {
 		return err
 	}
@@ -74,7 +316,7 @@ func newTransaction(ctx *cli.Context) error {
 		return err
 	}
 
-	sk, err := account.ParsePrivateKey(ctx.String("private_key"))
+	sk, err := readPrivateKey(ctx)
 	if err != nil {
 		return err
 	}
@@ -88,22 +330,86 @@ func newTransaction(ctx *cli.Context) error {
 		return err
 	}
 
+	id, err := transaction.TransactionID(txn)
+	if err != nil {
+		return err
+	}
+
 	fmt.Println(txn)
+	fmt.Println(id)
 	return nil
 }
 
+// readPrivateKey returns the private key from --keystore or --private_key.
+func readPrivateKey(ctx *cli.Context) (*account.PrivateKey, error) {
+	switch {
+	case ctx.IsSet("keystore"):
+		acc, err := loadKeystore(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return acc.PrivateKey(), nil
+	case ctx.IsSet("private_key"):
+		return account.ParsePrivateKey(ctx.String("private_key"))
+	default:
+		return nil, errMissingKey
+	}
+}
+
 func decryptRecord(ctx *cli.Context) error {
-	vk, err := account.ParseViewKey(ctx.String("viewkey"))
+	var rec *record.Record
+	if ctx.IsSet("watch_only") {
+		buf, err := ioutil.ReadFile(ctx.String("watch_only"))
+		if err != nil {
+			return err
+		}
+
+		var w account.WatchOnly
+		if err := json.Unmarshal(buf, &w); err != nil {
+			return err
+		}
+
+		rec, err = record.DecryptOwnedRecord(ctx.String("ciphertext"), &w)
+		if err != nil {
+			return err
+		}
+	} else {
+		vk, err := account.ParseViewKey(ctx.String("viewkey"))
+		if err != nil {
+			return err
+		}
+
+		rec, err = record.DecryptRecord(ctx.String("ciphertext"), vk)
+		if err != nil {
+			return err
+		}
+	}
+
+	resp, err := json.Marshal(rec)
 	if err != nil {
 		return err
 	}
 
-	rec, err := record.DecryptRecord(ctx.String("ciphertext"), vk)
+	fmt.Printf("%s\n", resp)
+	return nil
+}
+
+func decodeTransaction(ctx *cli.Context) error {
+	tx, err := transaction.DecodeTransaction(ctx.String("txn"))
 	if err != nil {
 		return err
 	}
 
-	resp, err := json.Marshal(rec)
+	ids, err := transaction.ComputeIDs(ctx.String("txn"))
+	if err != nil {
+		return err
+	}
+
+	if err := ids.Apply(tx); err != nil {
+		return err
+	}
+
+	resp, err := json.Marshal(tx)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/pinestreetlabs/aleo-wallet-sdk/account"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAccountKeystore(t *testing.T) {
+	want := account.JSON{
+		PrivateKey: "APrivateKey1zkp8cC4jgHEBnbtu3xxs1Ndja2EMizcvTRDq5Nikdkukg1p",
+		ViewKey:    "AViewKey1iAf6a7fv6ELA4ECwAth1hDNUJJNNoWNThmREjpybqder",
+		Address:    "aleo1d5hg2z3ma00382pngntdp68e74zv54jdxy249qhaujhks9c72yrs33ddah",
+	}
+
+	buf, _ := json.Marshal(want)
+	acc := &account.Account{}
+	if err := json.Unmarshal(buf, acc); err != nil {
+		t.Fatal(err)
+	}
+
+	ks, err := account.EncryptKeystore(acc, "hunter2", &account.KeystoreConfig{ScryptN: account.LightScryptN})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	keystore := filepath.Join(dir, "keystore.json")
+	buf, _ = json.Marshal(ks)
+	if err := ioutil.WriteFile(keystore, buf, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	password := filepath.Join(dir, "password")
+	if err := ioutil.WriteFile(password, []byte("hunter2\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := run(t, "account", "--keystore="+keystore, "--password_file="+password)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got account.JSON
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got != want {
+		t.Fatalf("got %v want %v", got, want)
+	}
+
+	out, err = run(t, "account", "--keystore="+keystore, "--password_file="+password, "--watch_only")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var watch account.WatchOnlyJSON
+	if err := json.Unmarshal([]byte(out), &watch); err != nil {
+		t.Fatal(err)
+	}
+	if watch.ViewKey != want.ViewKey || watch.Address != want.Address || strings.Contains(out, want.PrivateKey) {
+		t.Fatalf("got %s", out)
+	}
+
+	if err := ioutil.WriteFile(password, []byte("hunter3\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := run(t, "account", "--keystore="+keystore, "--password_file="+password); !errors.Is(err, account.ErrInvalidPassword) {
+		t.Fatalf("got %v want %v", err, account.ErrInvalidPassword)
+	}
+
+	if _, err := run(t, "account"); !errors.Is(err, errMissingKey) {
+		t.Fatalf("got %v want %v", err, errMissingKey)
+	}
+}
+
+func TestCreateKeystoreBackup(t *testing.T) {
+	keystore := filepath.Join(t.TempDir(), "keystore.json")
+
+	for _, flag := range []string{"--hd", "--mnemonic"} {
+		if _, err := run(t, "create", "--keystore="+keystore, flag); !errors.Is(err, errBackupRequired) {
+			t.Fatalf("got %v want %v", err, errBackupRequired)
+		}
+	}
+
+	if _, err := os.Stat(keystore); !os.IsNotExist(err) {
+		t.Fatalf("keystore written : %v", err)
+	}
+}
+
+func TestSplit(t *testing.T) {
+	key := "APrivateKey1zkp8cC4jgHEBnbtu3xxs1Ndja2EMizcvTRDq5Nikdkukg1p"
+
+	out, err := run(t, "split", "--threshold=2", "--shares=3", "--private_key="+key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(out, "\n")
+	if len(lines) != 3 {
+		t.Fatalf("got %d shares want 3", len(lines))
+	}
+
+	var shares []account.Share
+	for _, line := range lines[1:] {
+		share, err := account.ParseShare(line)
+		if err != nil {
+			t.Fatal(err)
+		}
+		shares = append(shares, *share)
+	}
+
+	seed, err := account.CombineShares(shares)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := (account.PrivateKey{Seed: seed}).String(); got != key {
+		t.Fatalf("got %s want %s", got, key)
+	}
+
+	if _, err := run(t, "combine", "--share="+lines[0]); err == nil {
+		t.Fatal("expected err")
+	}
+}
@@ -1,6 +1,10 @@
 package main
 
-import "github.com/urfave/cli"
+import (
+	"github.com/pinestreetlabs/aleo-wallet-sdk/account"
+	"github.com/pinestreetlabs/aleo-wallet-sdk/ids"
+	"github.com/urfave/cli"
+)
 
 var newAccountCommand = cli.Command{
 	Name:     "create",
@@ -8,6 +12,11 @@ var newAccountCommand = cli.Command{
 	Usage:    "Create a new Aleo account.",
 	Description: `
 	The create command is used to create a new Aleo account.
+	With --hd, the seed is a master seed and the account is derived along --path;
+	back up the printed seed to recover every account derived from it.
+	With --mnemonic, the seed is printed as 24 words instead, optionally protected by --passphrase.
+	With --keystore, the account is encrypted with a password and only its address is printed.
+	Adding --hd or --mnemonic also prints the seed or mnemonic in the clear, which requires --backup.
 	`,
 	Flags: []cli.Flag{
 		cli.StringFlag{
@@ -15,34 +24,260 @@ var newAccountCommand = cli.Command{
 			Usage:    "base64 encoded 32 byte seed",
 			Required: false,
 		},
+		cli.BoolFlag{
+			Name:  "hd",
+			Usage: "derive the account from the seed as a master seed",
+		},
+		cli.StringFlag{
+			Name:  "path",
+			Value: account.DefaultPath,
+			Usage: "hardened derivation path of the account with --hd",
+		},
+		cli.BoolFlag{
+			Name:  "mnemonic",
+			Usage: "print the seed as a 24 word mnemonic instead of base64",
+		},
+		cli.StringFlag{
+			Name:  "passphrase",
+			Usage: "passphrase protecting the mnemonic, needed again to restore it",
+		},
+		cli.StringFlag{
+			Name:  "keystore",
+			Usage: "write the account to a new encrypted keystore file instead of printing its keys",
+		},
+		cli.StringFlag{
+			Name:  "password_file",
+			Usage: "file holding the keystore password, prompted for if not set",
+		},
+		cli.BoolFlag{
+			Name:  "backup",
+			Usage: "with --keystore, also print the seed with --hd or the mnemonic with --mnemonic",
+		},
 	},
 	Action: newAccount,
 }
 
+var restoreAccountCommand = cli.Command{
+	Name:     "restore",
+	Category: "wallet",
+	Usage:    "Restore an Aleo account from a mnemonic.",
+	Description: `
+	The restore command recovers the seed from a mnemonic printed by create --mnemonic
+	and prints it with its account. Pass --hd and --path if the account was created with them.
+	A wrong passphrase restores a different account, so compare the address before use.
+	`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:     "mnemonic",
+			Usage:    "24 word mnemonic",
+			Required: true,
+		},
+		cli.StringFlag{
+			Name:  "passphrase",
+			Usage: "passphrase given when the mnemonic was created",
+		},
+		cli.BoolFlag{
+			Name:  "hd",
+			Usage: "derive the account from the seed as a master seed",
+		},
+		cli.StringFlag{
+			Name:  "path",
+			Value: account.DefaultPath,
+			Usage: "hardened derivation path of the account with --hd",
+		},
+	},
+	Action: restoreAccount,
+}
+
+var checkMnemonicCommand = cli.Command{
+	Name:     "check_mnemonic",
+	Category: "wallet",
+	Usage:    "Check a mnemonic backup.",
+	Description: `
+	The check_mnemonic command decodes a mnemonic, encodes the seed again and compares the two,
+	then prints the address of its account. With --from or --address, the seed or address
+	must match as well, which catches a mistyped passphrase.
+	`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:     "mnemonic",
+			Usage:    "24 word mnemonic",
+			Required: true,
+		},
+		cli.StringFlag{
+			Name:  "passphrase",
+			Usage: "passphrase given when the mnemonic was created",
+		},
+		cli.StringFlag{
+			Name:  "from",
+			Usage: "base64 encoded 32 byte seed the mnemonic must encode",
+		},
+		cli.StringFlag{
+			Name:  "address",
+			Usage: "address the mnemonic must restore",
+		},
+		cli.BoolFlag{
+			Name:  "hd",
+			Usage: "derive the account from the seed as a master seed",
+		},
+		cli.StringFlag{
+			Name:  "path",
+			Value: account.DefaultPath,
+			Usage: "hardened derivation path of the account with --hd",
+		},
+	},
+	Action: checkMnemonic,
+}
+
+var deriveAccountCommand = cli.Command{
+	Name:     "derive",
+	Category: "wallet",
+	Usage:    "Derive an Aleo account from a master seed.",
+	Description: `
+	The derive command derives the account at a hardened path, such as m/0'/1',
+	from a master seed printed by create --hd.
+	`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:     "from",
+			Usage:    "base64 encoded 32 byte master seed",
+			Required: true,
+		},
+		cli.StringFlag{
+			Name:  "path",
+			Value: account.DefaultPath,
+			Usage: "hardened derivation path of the account",
+		},
+	},
+	Action: deriveAccount,
+}
+
 var fromAccountCommand = cli.Command{
 	Name:     "account",
 	Category: "wallet",
 	Usage:    "View account address using a private key.",
 	Description: `
 	The account command is used to view the address of an account using a private key.
+	With --keystore, the private key is decrypted from a keystore file written by create --keystore.
+	With --watch_only, only the view key and address are printed, as read by watch and decrypt_record.
 	`,
 	Flags: []cli.Flag{
 		cli.StringFlag{
-			Name:     "from",
-			Usage:    "base32m encoded private key",
-			Required: true,
+			Name:  "from",
+			Usage: "base32m encoded private key",
+		},
+		cli.StringFlag{
+			Name:  "keystore",
+			Usage: "encrypted keystore file, instead of --from",
+		},
+		cli.StringFlag{
+			Name:  "password_file",
+			Usage: "file holding the keystore password, prompted for if not set",
+		},
+		cli.BoolFlag{
+			Name:  "watch_only",
+			Usage: "print the account without its private key",
 		},
 	},
 	Action: fromAccount,
 }
 
+var splitKeyCommand = cli.Command{
+	Name:     "split",
+	Category: "wallet",
+	Usage:    "Split a private key into shares.",
+	Description: `
+	The split command splits the seed of a private key into --shares shares, any --threshold
+	of which recover it with combine, and prints one share per line. Fewer shares reveal nothing
+	about the key beyond a 32-bit fingerprint of its seed, which each share carries to check the
+	recovered key. Each share is bech32m encoded, so a mistyped share is rejected.
+	`,
+	Flags: []cli.Flag{
+		cli.IntFlag{
+			Name:     "threshold",
+			Usage:    "number of shares needed to recover the key",
+			Required: true,
+		},
+		cli.IntFlag{
+			Name:     "shares",
+			Usage:    "number of shares to create, at most 255",
+			Required: true,
+		},
+		cli.StringFlag{
+			Name:  "private_key",
+			Usage: "private key to split",
+		},
+		cli.StringFlag{
+			Name:  "keystore",
+			Usage: "encrypted keystore file holding the private key, instead of --private_key",
+		},
+		cli.StringFlag{
+			Name:  "password_file",
+			Usage: "file holding the keystore password, prompted for if not set",
+		},
+	},
+	Action: splitKey,
+}
+
+var combineSharesCommand = cli.Command{
+	Name:     "combine",
+	Category: "wallet",
+	Usage:    "Recover a private key from shares.",
+	Description: `
+	The combine command recovers the account split with split from at least threshold shares
+	and prints it, or writes it to a new --keystore and prints its address.
+	Shares from different splits, or a corrupted share, are rejected.
+	`,
+	Flags: []cli.Flag{
+		cli.StringSliceFlag{
+			Name:     "share",
+			Usage:    "share printed by split, repeated for each share",
+			Required: true,
+		},
+		cli.StringFlag{
+			Name:  "keystore",
+			Usage: "write the account to a new encrypted keystore file instead of printing its keys",
+		},
+		cli.StringFlag{
+			Name:  "password_file",
+			Usage: "file holding the keystore password, prompted for if not set",
+		},
+	},
+	Action: combineShares,
+}
+
+var watchAccountCommand = cli.Command{
+	Name:     "watch",
+	Category: "wallet",
+	Usage:    "Create a watch-only account from a view key and address.",
+	Description: `
+	The watch command checks that the address belongs to the view key and prints
+	a watch-only account, which decrypts records without holding a private key.
+	`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:     "viewkey",
+			Usage:    "view key of the account",
+			Required: true,
+		},
+		cli.StringFlag{
+			Name:     "address",
+			Usage:    "address of the account",
+			Required: true,
+		},
+	},
+	Action: watchAccount,
+}
+
 var newTransactionCommand = cli.Command{
 	Name:     "send",
 	Category: "wallet",
 	Usage:    "Create a basic transfer transaction.",
 	Description: `
 	The send command creates a single transfer transaction that consumes
-	a single record and returns a serialized transaction in hex.
+	a single record and returns a serialized transaction in hex, followed
+	by its transaction ID on the next line. It is signed with --private_key
+	or with the key decrypted from --keystore.
 	`,
 	Action: newTransaction,
 	Flags: []cli.Flag{
@@ -67,15 +302,22 @@ var newTransactionCommand = cli.Command{
 			Required: true,
 		},
 		cli.StringFlag{
-			Name:     "private_key",
-			Usage:    "private key to sign transaction",
-			Required: true,
+			Name:  "private_key",
+			Usage: "private key to sign transaction",
 		},
 		cli.StringFlag{
 			Name:     "record",
 			Usage:    "the ciphertext of the record to consume",
 			Required: true,
 		},
+		cli.StringFlag{
+			Name:  "keystore",
+			Usage: "encrypted keystore file holding the private key, instead of --private_key",
+		},
+		cli.StringFlag{
+			Name:  "password_file",
+			Usage: "file holding the keystore password, prompted for if not set",
+		},
 	},
 }
 
@@ -84,13 +326,17 @@ var getBlockCommand = cli.Command{
 	Category: "rpc",
 	Usage:    "Get a block.",
 	Description: `
-	Gets a block from SnarkOS.
+	Gets a block by height or hash from SnarkOS.
 	`,
 	Flags: []cli.Flag{
 		cli.Int64Flag{
-			Name:     "height",
-			Usage:    "block height",
-			Required: true,
+			Name:  "height",
+			Usage: "block height",
+		},
+		cli.GenericFlag{
+			Name:  "hash",
+			Usage: "block hash, instead of the height",
+			Value: new(ids.BlockHash),
 		},
 	},
 	Action: getBlock,
@@ -160,9 +406,10 @@ var getTransactionCommand = cli.Command{
 	Gets a transaction from SnarkOS.
 	`,
 	Flags: []cli.Flag{
-		cli.StringFlag{
+		cli.GenericFlag{
 			Name:     "id",
 			Usage:    "transaction_id",
+			Value:    new(ids.TransactionID),
 			Required: true,
 		},
 	},
@@ -177,9 +424,10 @@ var getTransitionCommand = cli.Command{
 	Gets a transition from SnarkOS.
 	`,
 	Flags: []cli.Flag{
-		cli.StringFlag{
+		cli.GenericFlag{
 			Name:     "id",
 			Usage:    "transition_id",
+			Value:    new(ids.TransitionID),
 			Required: true,
 		},
 	},
@@ -194,9 +442,10 @@ var getBlockHeightCommand = cli.Command{
 	Gets a block height from SnarkOS.
 	`,
 	Flags: []cli.Flag{
-		cli.StringFlag{
+		cli.GenericFlag{
 			Name:     "hash",
 			Usage:    "block hash",
+			Value:    new(ids.BlockHash),
 			Required: true,
 		},
 	},
@@ -250,9 +499,10 @@ var getCiphertextCommand = cli.Command{
 	Gets a ciphertext given the ciphertext ID from SnarkOS.
 	`,
 	Flags: []cli.Flag{
-		cli.StringFlag{
+		cli.GenericFlag{
 			Name:     "id",
 			Usage:    "ciphertext id",
+			Value:    new(ids.CiphertextID),
 			Required: true,
 		},
 	},
@@ -334,21 +584,107 @@ var getLedgerProofCommand = cli.Command{
 	Returns the ledger proof for the given commitment with the current ledger root.
 	`,
 	Flags: []cli.Flag{
-		cli.StringFlag{
+		cli.GenericFlag{
 			Name:     "commitment",
 			Usage:    "The record commitment to generate a ledger proof of inclusion for.",
+			Value:    new(ids.Commitment),
 			Required: true,
 		},
 	},
 	Action: getLedgerProof,
 }
 
+var getBestBlockHashCommand = cli.Command{
+	Name:     "getbestblockhash",
+	Category: "rpc",
+	Usage:    "Get the best block hash.",
+	Description: `
+	Gets the hash of the tip of the best chain from SnarkOS.
+	`,
+	Action: getBestBlockHash,
+}
+
+var latestBlockHashCommand = cli.Command{
+	Name:     "latestblockhash",
+	Category: "rpc",
+	Usage:    "Get the latest block hash.",
+	Description: `
+	Gets the latest block hash from SnarkOS.
+	`,
+	Action: latestBlockHash,
+}
+
+var latestCumulativeWeightCommand = cli.Command{
+	Name:     "latestcumulativeweight",
+	Category: "rpc",
+	Usage:    "Get the cumulative weight of the best chain.",
+	Description: `
+	Gets the cumulative proof-of-work weight of the best chain from SnarkOS.
+	`,
+	Action: latestCumulativeWeight,
+}
+
+var getConnectedPeersCommand = cli.Command{
+	Name:     "getconnectedpeers",
+	Category: "rpc",
+	Usage:    "Get the connected peers.",
+	Description: `
+	Gets the peers connected to SnarkOS.
+	`,
+	Action: getConnectedPeers,
+}
+
+var getNodeStateCommand = cli.Command{
+	Name:     "getnodestate",
+	Category: "rpc",
+	Usage:    "Get the node state.",
+	Description: `
+	Gets the state of SnarkOS, including its peers, latest block and status.
+	`,
+	Action: getNodeState,
+}
+
+var getMemoryPoolCommand = cli.Command{
+	Name:     "getmemorypool",
+	Category: "rpc",
+	Usage:    "Get the memory pool.",
+	Description: `
+	Gets the unconfirmed transactions in the memory pool of SnarkOS.
+	`,
+	Action: getMemoryPool,
+}
+
+var getBlockTemplateCommand = cli.Command{
+	Name:     "getblocktemplate",
+	Category: "rpc",
+	Usage:    "Get a block template.",
+	Description: `
+	Gets the template for the next block to mine from SnarkOS.
+	`,
+	Action: getBlockTemplate,
+}
+
+var rawRPCCommand = cli.Command{
+	Name:      "rpc",
+	Category:  "rpc",
+	Usage:     "Send any JSON-RPC method.",
+	ArgsUsage: "<method> [params...]",
+	Description: `
+	The rpc command sends a JSON-RPC method to SnarkOS and prints the raw result.
+	Each param is a JSON literal, so strings must be quoted:
+
+	nemean --rpc=127.0.0.1:3032 rpc getblockheight '"ab1..."'
+	`,
+	Action: rawRPC,
+}
+
 var decryptRecordCommand = cli.Command{
 	Name:     "decrypt_record",
 	Category: "wallet",
 	Usage:    "Decrypts a record.",
 	Description: `
-	Decrypts a record using a view key.
+	Decrypts a record using a view key, or a watch-only account file written by watch,
+	in which case the record must also be owned by its address.
 	`,
 	Flags: []cli.Flag{
 		cli.StringFlag{
@@ -357,9 +693,12 @@ var decryptRecordCommand = cli.Command{
 			Required: true,
 		},
 		cli.StringFlag{
-			Name:     "viewkey",
-			Usage:    "The view key that can decrypt the record.",
-			Required: true,
+			Name:  "viewkey",
+			Usage: "The view key that can decrypt the record.",
+		},
+		cli.StringFlag{
+			Name:  "watch_only",
+			Usage: "A watch-only account file, instead of --viewkey.",
 		},
 	},
 	Action: decryptRecord,
@@ -408,3 +747,22 @@ var newRecordCommand = cli.Command{
 	},
 	Action: newRecord,
 }
+
+var decodeTransactionCommand = cli.Command{
+	Name:     "decode_transaction",
+	Category: "wallet",
+	Usage:    "Decodes a transaction.",
+	Description: `
+	Decodes a serialized transaction in hex, such as the output of send,
+	and prints it as JSON, including its transaction, transition and
+	ciphertext IDs.
+	`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:     "txn",
+			Usage:    "The serialized transaction in hex.",
+			Required: true,
+		},
+	},
+	Action: decodeTransaction,
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/pinestreetlabs/aleo-wallet-sdk/account"
+	"github.com/urfave/cli"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+var errEmptyPassword = errors.New("empty password")
+var errPasswordMismatch = errors.New("passwords do not match")
+
+// stdin is shared by password prompts so that piped input is not lost between them.
+var stdin = bufio.NewReader(os.Stdin)
+
+// saveKeystore encrypts acc and writes it to --keystore, which must not exist yet.
+func saveKeystore(ctx *cli.Context, acc *account.Account) error {
+	password, err := readPassword(ctx, true)
+	if err != nil {
+		return err
+	}
+
+	ks, err := account.EncryptKeystore(acc, password, nil)
+	if err != nil {
+		return err
+	}
+
+	buf, err := json.MarshalIndent(ks, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(ctx.String("keystore"), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+
+	if _, err := f.Write(append(buf, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+// loadKeystore reads and decrypts the account in --keystore.
+func loadKeystore(ctx *cli.Context) (*account.Account, error) {
+	buf, err := ioutil.ReadFile(ctx.String("keystore"))
+	if err != nil {
+		return nil, err
+	}
+
+	var ks account.Keystore
+	if err := json.Unmarshal(buf, &ks); err != nil {
+		return nil, fmt.Errorf("%s : %w", ctx.String("keystore"), err)
+	}
+
+	password, err := readPassword(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return account.DecryptKeystore(&ks, password)
+}
+
+// readPassword reads the password from --password_file or prompts for it, twice if confirm is set.
+func readPassword(ctx *cli.Context, confirm bool) (string, error) {
+	if ctx.IsSet("password_file") {
+		buf, err := ioutil.ReadFile(ctx.String("password_file"))
+		if err != nil {
+			return "", err
+		}
+
+		password := strings.TrimRight(string(buf), "\r\n")
+		if password == "" {
+			return "", errEmptyPassword
+		}
+		return password, nil
+	}
+
+	password, err := promptPassword("Password: ")
+	if err != nil {
+		return "", err
+	}
+	if password == "" {
+		return "", errEmptyPassword
+	}
+
+	if confirm {
+		again, err := promptPassword("Repeat password: ")
+		if err != nil {
+			return "", err
+		}
+		if again != password {
+			return "", errPasswordMismatch
+		}
+	}
+
+	return password, nil
+}
+
+// promptPassword reads a line from stdin, turning off echo when it is a terminal.
+func promptPassword(prompt string) (string, error) {
+	fmt.Fprint(os.Stderr, prompt)
+
+	if fi, err := os.Stdin.Stat(); err == nil && fi.Mode()&os.ModeCharDevice != 0 {
+		if err := stty("-echo"); err == nil {
+			defer func() {
+				stty("echo")
+				fmt.Fprintln(os.Stderr)
+			}()
+		}
+	}
+
+	line, err := stdin.ReadString('\n')
+	if err != nil && !(errors.Is(err, io.EOF) && line != "") {
+		return "", err
+	}
+
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+func stty(arg string) error {
+	cmd := exec.Command("stty", arg)
+	cmd.Stdin = os.Stdin
+	return cmd.Run()
+}
@@ -0,0 +1,376 @@
+// Package ids provides typed bech32m identifiers for chain objects.
+//
+// Each kind of identifier is a distinct string type, so a commitment can never be passed where a
+// serial number is expected. Identifiers are validated when parsed, set from a flag or unmarshalled;
+// the empty string is accepted as the zero value.
+package ids
+
+import (
+	"errors"
+	"fmt"
+	"github.com/btcsuite/btcutil/bech32"
+)
+
+// Size is the length in bytes of every identifier.
+const Size = 32
+
+// Human-readable prefixes of each kind of identifier.
+const (
+	blockHashPrefix     = "ab"
+	transactionIDPrefix = "at"
+	transitionIDPrefix  = "as"
+	commitmentPrefix    = "cm"
+	serialNumberPrefix  = "sn"
+	ledgerRootPrefix    = "al"
+	ciphertextIDPrefix  = "ar"
+)
+
+var errInvalidPrefix = errors.New("invalid hrp")
+var errNotBech32m = errors.New("identifier is not encoded in bech32m")
+var errInvalidLen = errors.New("invalid identifier length")
+
+// BlockHash identifies a block, such as "ab1...".
+type BlockHash string
+
+// ParseBlockHash validates s as a block hash.
+func ParseBlockHash(s string) (BlockHash, error) {
+	if _, err := decode(blockHashPrefix, s); err != nil {
+		return "", fmt.Errorf("ParseBlockHash : %w", err)
+	}
+
+	return BlockHash(s), nil
+}
+
+// NewBlockHash encodes raw bytes as a block hash.
+func NewBlockHash(b []byte) (BlockHash, error) {
+	s, err := encode(blockHashPrefix, b)
+	if err != nil {
+		return "", fmt.Errorf("NewBlockHash : %w", err)
+	}
+
+	return BlockHash(s), nil
+}
+
+// Bytes returns the raw bytes of the block hash.
+func (id BlockHash) Bytes() ([]byte, error) {
+	return decode(blockHashPrefix, string(id))
+}
+
+// String implements the stringer interface for BlockHash.
+func (id BlockHash) String() string {
+	return string(id)
+}
+
+// Set implements flag.Value for BlockHash.
+func (id *BlockHash) Set(s string) error {
+	return set(blockHashPrefix, s, (*string)(id))
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler for BlockHash.
+func (id *BlockHash) UnmarshalText(b []byte) error {
+	return set(blockHashPrefix, string(b), (*string)(id))
+}
+
+// TransactionID identifies a transaction, such as "at1...".
+type TransactionID string
+
+// ParseTransactionID validates s as a transaction ID.
+func ParseTransactionID(s string) (TransactionID, error) {
+	if _, err := decode(transactionIDPrefix, s); err != nil {
+		return "", fmt.Errorf("ParseTransactionID : %w", err)
+	}
+
+	return TransactionID(s), nil
+}
+
+// NewTransactionID encodes raw bytes as a transaction ID.
+func NewTransactionID(b []byte) (TransactionID, error) {
+	s, err := encode(transactionIDPrefix, b)
+	if err != nil {
+		return "", fmt.Errorf("NewTransactionID : %w", err)
+	}
+
+	return TransactionID(s), nil
+}
+
+// Bytes returns the raw bytes of the transaction ID.
+func (id TransactionID) Bytes() ([]byte, error) {
+	return decode(transactionIDPrefix, string(id))
+}
+
+// String implements the stringer interface for TransactionID.
+func (id TransactionID) String() string {
+	return string(id)
+}
+
+// Set implements flag.Value for TransactionID.
+func (id *TransactionID) Set(s string) error {
+	return set(transactionIDPrefix, s, (*string)(id))
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler for TransactionID.
+func (id *TransactionID) UnmarshalText(b []byte) error {
+	return set(transactionIDPrefix, string(b), (*string)(id))
+}
+
+// TransitionID identifies a transition, such as "as1...".
+type TransitionID string
+
+// ParseTransitionID validates s as a transition ID.
+func ParseTransitionID(s string) (TransitionID, error) {
+	if _, err := decode(transitionIDPrefix, s); err != nil {
+		return "", fmt.Errorf("ParseTransitionID : %w", err)
+	}
+
+	return TransitionID(s), nil
+}
+
+// NewTransitionID encodes raw bytes as a transition ID.
+func NewTransitionID(b []byte) (TransitionID, error) {
+	s, err := encode(transitionIDPrefix, b)
+	if err != nil {
+		return "", fmt.Errorf("NewTransitionID : %w", err)
+	}
+
+	return TransitionID(s), nil
+}
+
+// Bytes returns the raw bytes of the transition ID.
+func (id TransitionID) Bytes() ([]byte, error) {
+	return decode(transitionIDPrefix, string(id))
+}
+
+// String implements the stringer interface for TransitionID.
+func (id TransitionID) String() string {
+	return string(id)
+}
+
+// Set implements flag.Value for TransitionID.
+func (id *TransitionID) Set(s string) error {
+	return set(transitionIDPrefix, s, (*string)(id))
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler for TransitionID.
+func (id *TransitionID) UnmarshalText(b []byte) error {
+	return set(transitionIDPrefix, string(b), (*string)(id))
+}
+
+// Commitment identifies a record by its commitment, such as "cm1...".
+type Commitment string
+
+// ParseCommitment validates s as a record commitment.
+func ParseCommitment(s string) (Commitment, error) {
+	if _, err := decode(commitmentPrefix, s); err != nil {
+		return "", fmt.Errorf("ParseCommitment : %w", err)
+	}
+
+	return Commitment(s), nil
+}
+
+// NewCommitment encodes raw bytes as a record commitment.
+func NewCommitment(b []byte) (Commitment, error) {
+	s, err := encode(commitmentPrefix, b)
+	if err != nil {
+		return "", fmt.Errorf("NewCommitment : %w", err)
+	}
+
+	return Commitment(s), nil
+}
+
+// Bytes returns the raw bytes of the commitment.
+func (id Commitment) Bytes() ([]byte, error) {
+	return decode(commitmentPrefix, string(id))
+}
+
+// String implements the stringer interface for Commitment.
+func (id Commitment) String() string {
+	return string(id)
+}
+
+// Set implements flag.Value for Commitment.
+func (id *Commitment) Set(s string) error {
+	return set(commitmentPrefix, s, (*string)(id))
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler for Commitment.
+func (id *Commitment) UnmarshalText(b []byte) error {
+	return set(commitmentPrefix, string(b), (*string)(id))
+}
+
+// SerialNumber identifies a spent record, such as "sn1...".
+type SerialNumber string
+
+// ParseSerialNumber validates s as a serial number.
+func ParseSerialNumber(s string) (SerialNumber, error) {
+	if _, err := decode(serialNumberPrefix, s); err != nil {
+		return "", fmt.Errorf("ParseSerialNumber : %w", err)
+	}
+
+	return SerialNumber(s), nil
+}
+
+// NewSerialNumber encodes raw bytes as a serial number.
+func NewSerialNumber(b []byte) (SerialNumber, error) {
+	s, err := encode(serialNumberPrefix, b)
+	if err != nil {
+		return "", fmt.Errorf("NewSerialNumber : %w", err)
+	}
+
+	return SerialNumber(s), nil
+}
+
+// Bytes returns the raw bytes of the serial number.
+func (id SerialNumber) Bytes() ([]byte, error) {
+	return decode(serialNumberPrefix, string(id))
+}
+
+// String implements the stringer interface for SerialNumber.
+func (id SerialNumber) String() string {
+	return string(id)
+}
+
+// Set implements flag.Value for SerialNumber.
+func (id *SerialNumber) Set(s string) error {
+	return set(serialNumberPrefix, s, (*string)(id))
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler for SerialNumber.
+func (id *SerialNumber) UnmarshalText(b []byte) error {
+	return set(serialNumberPrefix, string(b), (*string)(id))
+}
+
+// LedgerRoot identifies a ledger state, such as "al1...".
+type LedgerRoot string
+
+// ParseLedgerRoot validates s as a ledger root.
+func ParseLedgerRoot(s string) (LedgerRoot, error) {
+	if _, err := decode(ledgerRootPrefix, s); err != nil {
+		return "", fmt.Errorf("ParseLedgerRoot : %w", err)
+	}
+
+	return LedgerRoot(s), nil
+}
+
+// NewLedgerRoot encodes raw bytes as a ledger root.
+func NewLedgerRoot(b []byte) (LedgerRoot, error) {
+	s, err := encode(ledgerRootPrefix, b)
+	if err != nil {
+		return "", fmt.Errorf("NewLedgerRoot : %w", err)
+	}
+
+	return LedgerRoot(s), nil
+}
+
+// Bytes returns the raw bytes of the ledger root.
+func (id LedgerRoot) Bytes() ([]byte, error) {
+	return decode(ledgerRootPrefix, string(id))
+}
+
+// String implements the stringer interface for LedgerRoot.
+func (id LedgerRoot) String() string {
+	return string(id)
+}
+
+// Set implements flag.Value for LedgerRoot.
+func (id *LedgerRoot) Set(s string) error {
+	return set(ledgerRootPrefix, s, (*string)(id))
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler for LedgerRoot.
+func (id *LedgerRoot) UnmarshalText(b []byte) error {
+	return set(ledgerRootPrefix, string(b), (*string)(id))
+}
+
+// CiphertextID identifies a record ciphertext, such as "ar1...".
+type CiphertextID string
+
+// ParseCiphertextID validates s as a ciphertext ID.
+func ParseCiphertextID(s string) (CiphertextID, error) {
+	if _, err := decode(ciphertextIDPrefix, s); err != nil {
+		return "", fmt.Errorf("ParseCiphertextID : %w", err)
+	}
+
+	return CiphertextID(s), nil
+}
+
+// NewCiphertextID encodes raw bytes as a ciphertext ID.
+func NewCiphertextID(b []byte) (CiphertextID, error) {
+	s, err := encode(ciphertextIDPrefix, b)
+	if err != nil {
+		return "", fmt.Errorf("NewCiphertextID : %w", err)
+	}
+
+	return CiphertextID(s), nil
+}
+
+// Bytes returns the raw bytes of the ciphertext ID.
+func (id CiphertextID) Bytes() ([]byte, error) {
+	return decode(ciphertextIDPrefix, string(id))
+}
+
+// String implements the stringer interface for CiphertextID.
+func (id CiphertextID) String() string {
+	return string(id)
+}
+
+// Set implements flag.Value for CiphertextID.
+func (id *CiphertextID) Set(s string) error {
+	return set(ciphertextIDPrefix, s, (*string)(id))
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler for CiphertextID.
+func (id *CiphertextID) UnmarshalText(b []byte) error {
+	return set(ciphertextIDPrefix, string(b), (*string)(id))
+}
+
+// set validates s and stores it in dst. The empty string is the zero value.
+func set(prefix, s string, dst *string) error {
+	if s != "" {
+		if _, err := decode(prefix, s); err != nil {
+			return err
+		}
+	}
+
+	*dst = s
+	return nil
+}
+
+// decode validates the prefix, checksum and length of s and returns its raw bytes.
+func decode(prefix, s string) ([]byte, error) {
+	hrp, data, version, err := bech32.DecodeGeneric(s)
+	if err != nil {
+		return nil, fmt.Errorf("%s : %w", s, err)
+	}
+
+	if hrp != prefix {
+		return nil, fmt.Errorf("%s : %w : got %s want %s", s, errInvalidPrefix, hrp, prefix)
+	}
+
+	if version != bech32.VersionM {
+		return nil, fmt.Errorf("%s : %w", s, errNotBech32m)
+	}
+
+	b, err := bech32.ConvertBits(data, 5, 8, false)
+	if err != nil {
+		return nil, fmt.Errorf("%s : %w", s, err)
+	}
+
+	if len(b) != Size {
+		return nil, fmt.Errorf("%s : %w : got %d", s, errInvalidLen, len(b))
+	}
+
+	return b, nil
+}
+
+func encode(prefix string, b []byte) (string, error) {
+	if len(b) != Size {
+		return "", fmt.Errorf("%w : got %d", errInvalidLen, len(b))
+	}
+
+	data, err := bech32.ConvertBits(b, 8, 5, true)
+	if err != nil {
+		return "", err
+	}
+
+	return bech32.EncodeM(prefix, data)
+}
@@ -0,0 +1,145 @@
+package ids
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"github.com/btcsuite/btcutil/bech32"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := map[string]func(string) (string, error){
+		"ab1p40kjm5lva9h6arq695k5ng7c6dj7eu76qjch72ta4c23afkzq8qte2fj0": func(s string) (string, error) {
+			id, err := ParseBlockHash(s)
+			return string(id), err
+		},
+		"at1s8xaeuw706ruzc56v2nfum6l9ahwmruxp9ppjeqm93sr7phv6ursmsm9s3": func(s string) (string, error) {
+			id, err := ParseTransactionID(s)
+			return string(id), err
+		},
+		"as1lte63rhyl2lf80u2fg72v2ew0v5qehj8wwssgw0zvaa3kkn8lv8q9g4w4d": func(s string) (string, error) {
+			id, err := ParseTransitionID(s)
+			return string(id), err
+		},
+		"cm1a27p9slt2drlrau22c9e4p85075fpwd3ulg8lztv8kfg33u4hu9qtt282d": func(s string) (string, error) {
+			id, err := ParseCommitment(s)
+			return string(id), err
+		},
+		"sn1td5hpl8fztxhch9720xh5a8tyduudey47qcle9dy43cdyrthq5zqqjyg35": func(s string) (string, error) {
+			id, err := ParseSerialNumber(s)
+			return string(id), err
+		},
+		"al1wjefuedxe9q7kep2ps9932jql5dshk9523lhkgywxc87pzx3kcysuz0439": func(s string) (string, error) {
+			id, err := ParseLedgerRoot(s)
+			return string(id), err
+		},
+		"ar10uy00r2jneuqqkkfulgmchdrrv360sv4tsr7sv5h68j32g7p5gqqg5j6a0": func(s string) (string, error) {
+			id, err := ParseCiphertextID(s)
+			return string(id), err
+		},
+	}
+
+	for s, parse := range tests {
+		t.Run(s[:2], func(t *testing.T) {
+			id, err := parse(s)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if id != s {
+				t.Fatalf("got %s want %s", id, s)
+			}
+		})
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	short, _ := bech32.ConvertBits(make([]byte, Size-1), 8, 5, true)
+	shortID, _ := bech32.EncodeM("sn", short)
+
+	data, _ := bech32.ConvertBits(make([]byte, Size), 8, 5, true)
+	bech32ID, _ := bech32.Encode("sn", data)
+
+	tests := map[string]error{
+		// A commitment is not a serial number.
+		"cm1a27p9slt2drlrau22c9e4p85075fpwd3ulg8lztv8kfg33u4hu9qtt282d": errInvalidPrefix,
+		shortID:  errInvalidLen,
+		bech32ID: errNotBech32m,
+		// Bad checksum.
+		"sn1td5hpl8fztxhch9720xh5a8tyduudey47qcle9dy43cdyrthq5zqqjyg36": nil,
+		"sn1": nil,
+	}
+
+	for s, want := range tests {
+		_, err := ParseSerialNumber(s)
+		if err == nil || (want != nil && !errors.Is(err, want)) {
+			t.Fatalf("%s : got %v want %v", s, err, want)
+		}
+	}
+}
+
+func TestBytes(t *testing.T) {
+	raw := bytes.Repeat([]byte{0xab}, Size)
+
+	id, err := NewTransactionID(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := ParseTransactionID(string(id)); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := id.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(b, raw) {
+		t.Fatalf("got %x want %x", b, raw)
+	}
+
+	if _, err := NewTransactionID(raw[1:]); !errors.Is(err, errInvalidLen) {
+		t.Fatalf("got %v want %v", err, errInvalidLen)
+	}
+}
+
+func TestJSON(t *testing.T) {
+	type transition struct {
+		Commitments   []Commitment   `json:"commitments"`
+		SerialNumbers []SerialNumber `json:"serial_numbers"`
+		ID            TransitionID   `json:"transition_id"`
+	}
+
+	in := `{"commitments":["cm1a27p9slt2drlrau22c9e4p85075fpwd3ulg8lztv8kfg33u4hu9qtt282d"],"serial_numbers":["sn1td5hpl8fztxhch9720xh5a8tyduudey47qcle9dy43cdyrthq5zqqjyg35"],"transition_id":""}`
+
+	var v transition
+	if err := json.Unmarshal([]byte(in), &v); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(out) != in {
+		t.Fatalf("got %s want %s", out, in)
+	}
+
+	swapped := `{"serial_numbers":["cm1a27p9slt2drlrau22c9e4p85075fpwd3ulg8lztv8kfg33u4hu9qtt282d"]}`
+	if err := json.Unmarshal([]byte(swapped), &v); !errors.Is(err, errInvalidPrefix) {
+		t.Fatalf("got %v want %v", err, errInvalidPrefix)
+	}
+}
+
+func TestSet(t *testing.T) {
+	var id BlockHash
+	if err := id.Set("ab1p40kjm5lva9h6arq695k5ng7c6dj7eu76qjch72ta4c23afkzq8qte2fj0"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := id.Set("at1s8xaeuw706ruzc56v2nfum6l9ahwmruxp9ppjeqm93sr7phv6ursmsm9s3"); err == nil {
+		t.Fatal("expected err")
+	}
+}
@@ -3,10 +3,13 @@ package record
 import (
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/pinestreetlabs/aleo-wallet-sdk/account"
 )
 
+var errNotOwner = errors.New("record is not owned by the account")
+
 // Record is a fundamental data structure for encoding user assets and application state.
 type Record struct {
 	owner                *account.Address
@@ -117,3 +120,18 @@ func EncryptRecord(record *Record) (string, error) {
 func DecryptRecord(ciphertext string, viewKey *account.ViewKey) (*Record, error) {
 	return decryptRecord(ciphertext, viewKey)
 }
+
+// DecryptOwnedRecord decrypts a record with the view key of viewer, which may be a watch-only account,
+// and checks that the record is owned by its address.
+func DecryptOwnedRecord(ciphertext string, viewer account.Viewer) (*Record, error) {
+	rec, err := decryptRecord(ciphertext, viewer.ViewKey())
+	if err != nil {
+		return nil, fmt.Errorf("DecryptOwnedRecord : %w", err)
+	}
+
+	if rec.owner.String() != viewer.Address().String() {
+		return nil, fmt.Errorf("DecryptOwnedRecord : %w : owned by %s", errNotOwner, rec.owner)
+	}
+
+	return rec, nil
+}
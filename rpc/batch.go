@@ -0,0 +1,301 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/pinestreetlabs/aleo-wallet-sdk/ids"
+	"math/big"
+)
+
+var errMissingResponse = errors.New("missing response in batch")
+
+// BatchCall is a single call queued in a Batch.
+// Its result is decoded into the value passed when it was queued.
+type BatchCall struct {
+	Method string
+	Params []json.RawMessage
+	ID     string
+
+	// Error is set once the Batch is sent if the call failed.
+	Error error
+
+	result interface{}
+}
+
+// Batch queues calls to be sent to the node as a single JSON-RPC 2.0 batch request.
+type Batch struct {
+	client *Client
+	calls  []*BatchCall
+}
+
+// Batch returns an empty Batch bound to the client.
+func (c *Client) Batch() *Batch {
+	return &Batch{client: c}
+}
+
+// Len returns the number of queued calls.
+func (b *Batch) Len() int {
+	return len(b.calls)
+}
+
+// queue adds a call to the batch. A param that cannot be serialized fails only its own call.
+func (b *Batch) queue(method string, result interface{}, params ...interface{}) *BatchCall {
+	rawParams, err := marshalParams(params...)
+
+	call := &BatchCall{
+		Method: method,
+		Params: rawParams,
+		ID:     b.client.nextID(),
+		Error:  err,
+		result: result,
+	}
+
+	b.calls = append(b.calls, call)
+	return call
+}
+
+// Call queues a call to any method, leaving its raw result in res.
+func (b *Batch) Call(method string, res *json.RawMessage, params ...interface{}) *BatchCall {
+	return b.queue(method, res, params...)
+}
+
+// fail queues a call that is never sent.
+func (b *Batch) fail(method string, err error) *BatchCall {
+	call := &BatchCall{Method: method, ID: b.client.nextID(), Error: err}
+	b.calls = append(b.calls, call)
+	return call
+}
+
+// Send sends every queued call in a single request and stores each result or error on its BatchCall.
+// If the node rejects batch requests, with an error object or an HTTP error, the calls are sent one at a time instead.
+// The returned error only reports failures that affect the whole batch, such as transport errors.
+func (b *Batch) Send(ctx context.Context) error {
+	var pending []*BatchCall
+	var reqs []json.RawMessage
+	for _, call := range b.calls {
+		if call.Error != nil {
+			continue
+		}
+
+		req, err := newRequestBody(2, call.ID, call.Method, call.Params)
+		if err != nil {
+			call.Error = err
+			continue
+		}
+
+		pending = append(pending, call)
+		reqs = append(reqs, req)
+	}
+
+	if len(reqs) == 0 {
+		return nil
+	}
+
+	buf, err := b.client.invoker(ctx, BatchMethod, reqs)
+	if err != nil {
+		var httpErr *HTTPError
+		if errors.As(err, &httpErr) && rejectsBatch(httpErr) {
+			return b.sendSequential(ctx, pending)
+		}
+		return err
+	}
+
+	// A node without batch support answers with a single error object instead of an array.
+	if buf = bytes.TrimSpace(buf); len(buf) == 0 || buf[0] != '[' {
+		return b.sendSequential(ctx, pending)
+	}
+
+	var results []Result
+	if err := decodeJSON(buf, &results); err != nil {
+		return err
+	}
+
+	byID := make(map[string]*Result, len(results))
+	for i := range results {
+		byID[results[i].ID] = &results[i]
+	}
+
+	for _, call := range pending {
+		res, ok := byID[call.ID]
+		if !ok {
+			call.Error = fmt.Errorf("%w : id %s", errMissingResponse, call.ID)
+			continue
+		}
+		call.Error = decodeResult(res, call.result)
+	}
+
+	return nil
+}
+
+// rejectsBatch reports whether the node refused the batch request itself rather than failing to serve it:
+// a 4xx status, or a 5xx status whose body is a JSON-RPC error.
+func rejectsBatch(err *HTTPError) bool {
+	if err.StatusCode >= 400 && err.StatusCode < 500 {
+		return true
+	}
+
+	var res Result
+	return err.StatusCode >= 500 && json.Unmarshal(err.Body, &res) == nil && res.Error != nil
+}
+
+// sendSequential sends each call as its own request.
+func (b *Batch) sendSequential(ctx context.Context, calls []*BatchCall) error {
+	for _, call := range calls {
+		result, err := b.client.invoker(ctx, call.Method, call.Params)
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return ctxErr
+			}
+			call.Error = err
+			continue
+		}
+
+		if call.result != nil {
+			call.Error = decodeJSON(result, call.result)
+		}
+	}
+
+	return nil
+}
+
+// decodeResult unmarshals a response into v, surfacing any RPC error.
+func decodeResult(res *Result, v interface{}) error {
+	if res.Error != nil {
+		return res.Error
+	}
+
+	if v == nil {
+		return nil
+	}
+
+	return decodeJSON(res.Result, v)
+}
+
+// GetBlockHeight queues a getblockheight call.
+func (b *Batch) GetBlockHeight(blockHash ids.BlockHash, res *int64) *BatchCall {
+	return b.queue(getBlockHeightMethod, res, blockHash)
+}
+
+// GetBestBlockHash queues a getbestblockhash call.
+func (b *Batch) GetBestBlockHash(res *ids.BlockHash) *BatchCall {
+	return b.queue(getBestBlockHashMethod, res)
+}
+
+// GetBlock queues a getblock call.
+func (b *Batch) GetBlock(blockNumber int64, res *Block) *BatchCall {
+	return b.queue(getBlockMethod, res, blockNumber)
+}
+
+// GetBlockHash queues a getblockhash call.
+func (b *Batch) GetBlockHash(height int64, res *ids.BlockHash) *BatchCall {
+	return b.queue(getBlockHashMethod, res, height)
+}
+
+// GetTransaction queues a gettransaction call.
+func (b *Batch) GetTransaction(txID ids.TransactionID, res *GetTransactionResponse) *BatchCall {
+	return b.queue(getTransactionMethod, res, txID)
+}
+
+// GetTransition queues a gettransition call.
+func (b *Batch) GetTransition(transitionID ids.TransitionID, res *Transition) *BatchCall {
+	return b.queue(getTransitionMethod, res, transitionID)
+}
+
+// SendTransaction queues a sendtransaction call.
+func (b *Batch) SendTransaction(txHex string, res *ids.TransactionID) *BatchCall {
+	return b.queue(sendTransactionMethod, res, txHex)
+}
+
+// LatestLedgerRoot queues a latestledgerroot call.
+func (b *Batch) LatestLedgerRoot(res *ids.LedgerRoot) *BatchCall {
+	return b.queue(latestLedgerRootMethod, res)
+}
+
+// GetLedgerProof queues a getledgerproof call.
+func (b *Batch) GetLedgerProof(recordCommitment ids.Commitment, res *string) *BatchCall {
+	return b.queue(getLedgerProofMethod, res, recordCommitment)
+}
+
+// GetCiphertext queues a getciphertext call.
+func (b *Batch) GetCiphertext(id ids.CiphertextID, res *string) *BatchCall {
+	return b.queue(getCiphertextMethod, res, id)
+}
+
+// GetBlockHashes queues a getblockhashes call.
+func (b *Batch) GetBlockHashes(start, end int64, res *[]ids.BlockHash) *BatchCall {
+	if start > end {
+		return b.fail(getBlockHashesMethod, errors.New("start > end"))
+	}
+	return b.queue(getBlockHashesMethod, res, start, end)
+}
+
+// GetBlockHeader queues a getblockheader call.
+func (b *Batch) GetBlockHeader(height int64, res *BlockHeader) *BatchCall {
+	return b.queue(getBlockHeaderMethod, res, height)
+}
+
+// GetBlocks queues a getblocks call.
+func (b *Batch) GetBlocks(start, end int64, res *[]Block) *BatchCall {
+	if start > end {
+		return b.fail(getBlocksMethod, errors.New("start > end"))
+	}
+	return b.queue(getBlocksMethod, res, start, end)
+}
+
+// GetBlockTransactions queues a getblocktransactions call.
+func (b *Batch) GetBlockTransactions(height int64, res *Transactions) *BatchCall {
+	return b.queue(getBlockTransactionsMethod, res, height)
+}
+
+// LatestBlock queues a latestblock call.
+func (b *Batch) LatestBlock(res *Block) *BatchCall {
+	return b.queue(latestBlockMethod, res)
+}
+
+// LatestBlockHash queues a latestblockhash call.
+func (b *Batch) LatestBlockHash(res *ids.BlockHash) *BatchCall {
+	return b.queue(latestBlockHashMethod, res)
+}
+
+// LatestBlockHeader queues a latestblockheader call.
+func (b *Batch) LatestBlockHeader(res *BlockHeader) *BatchCall {
+	return b.queue(latestBlockHeaderMethod, res)
+}
+
+// LatestBlockHeight queues a latestblockheight call.
+func (b *Batch) LatestBlockHeight(res *int64) *BatchCall {
+	return b.queue(latestBlockHeightMethod, res)
+}
+
+// LatestBlockTransactions queues a latestblocktransactions call.
+func (b *Batch) LatestBlockTransactions(res *Transactions) *BatchCall {
+	return b.queue(latestBlockTransactionsMethod, res)
+}
+
+// GetConnectedPeers queues a getconnectedpeers call.
+func (b *Batch) GetConnectedPeers(res *[]string) *BatchCall {
+	return b.queue(getConnectedPeersMethod, res)
+}
+
+// LatestCumulativeWeight queues a latestcumulativeweight call.
+func (b *Batch) LatestCumulativeWeight(res *big.Int) *BatchCall {
+	return b.queue(latestCumulativeWeightMethod, res)
+}
+
+// GetBlockTemplate queues a getblocktemplate call.
+func (b *Batch) GetBlockTemplate(res *BlockTemplate) *BatchCall {
+	return b.queue(getBlockTemplateMethod, res)
+}
+
+// GetMemoryPool queues a getmemorypool call.
+func (b *Batch) GetMemoryPool(res *[]Transaction) *BatchCall {
+	return b.queue(getMemoryPoolMethod, res)
+}
+
+// GetNodeState queues a getnodestate call.
+func (b *Batch) GetNodeState(res *NodeState) *BatchCall {
+	return b.queue(getNodeStateMethod, res)
+}
@@ -0,0 +1,160 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/pinestreetlabs/aleo-wallet-sdk/ids"
+	"io/ioutil"
+	"net/http"
+	"testing"
+)
+
+func TestBatch(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+
+		var reqs []Request
+		if err := json.Unmarshal(body, &reqs); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		// Answer in reverse order to exercise ID correlation.
+		var results []Result
+		for i := len(reqs) - 1; i >= 0; i-- {
+			res := Result{ID: reqs[i].ID}
+			switch reqs[i].Method {
+			case getBlockHashMethod:
+				res.Result = json.RawMessage(`"` + testBlockHash + `"`)
+			case latestBlockHeightMethod:
+				res.Result = json.RawMessage(`7`)
+			default:
+				res.Error = &Error{Code: -32601, Message: "Method not found"}
+			}
+			results = append(results, res)
+		}
+		json.NewEncoder(w).Encode(results)
+	})
+
+	var hash ids.BlockHash
+	var height int64
+	var root ids.LedgerRoot
+
+	batch := client.Batch()
+	hashCall := batch.GetBlockHash(1, &hash)
+	heightCall := batch.LatestBlockHeight(&height)
+	rootCall := batch.LatestLedgerRoot(&root)
+	rangeCall := batch.GetBlocks(2, 1, nil)
+
+	if err := batch.Send(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if hashCall.Error != nil || hash != testBlockHash {
+		t.Fatalf("got %q, %v want %q", hash, hashCall.Error, testBlockHash)
+	}
+
+	if heightCall.Error != nil || height != 7 {
+		t.Fatalf("got %d, %v want %d", height, heightCall.Error, 7)
+	}
+
+	if rootCall.Error == nil {
+		t.Fatal("expected err")
+	}
+
+	if rangeCall.Error == nil {
+		t.Fatal("expected err")
+	}
+}
+
+func TestBatchFallback(t *testing.T) {
+	var requests int
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		body, _ := ioutil.ReadAll(r.Body)
+
+		var req Request
+		if err := json.Unmarshal(body, &req); err != nil {
+			w.Write([]byte(`{"jsonrpc":"2.0","error":{"code":-32600,"message":"Invalid request"},"id":null}`))
+			return
+		}
+
+		json.NewEncoder(w).Encode(Result{ID: req.ID, Result: json.RawMessage(`3`)})
+	})
+
+	var a, b int64
+	batch := client.Batch()
+	callA := batch.LatestBlockHeight(&a)
+	callB := batch.GetBlockHeight(testBlockHash, &b)
+
+	if err := batch.Send(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if callA.Error != nil || callB.Error != nil {
+		t.Fatal(callA.Error, callB.Error)
+	}
+
+	if a != 3 || b != 3 {
+		t.Fatalf("got %d, %d want 3, 3", a, b)
+	}
+
+	if requests != 3 {
+		t.Fatalf("got %d requests want %d", requests, 3)
+	}
+}
+
+func TestBatchFallbackHTTPError(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		status   int
+		body     string
+		fallback bool
+	}{
+		{"bad request", http.StatusBadRequest, "batch requests are not supported", true},
+		{"rpc error", http.StatusInternalServerError, `{"jsonrpc":"2.0","error":{"code":-32600,"message":"Invalid request"},"id":null}`, true},
+		{"unavailable", http.StatusServiceUnavailable, "unavailable", false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var requests int
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				body, _ := ioutil.ReadAll(r.Body)
+
+				var req Request
+				if err := json.Unmarshal(body, &req); err != nil {
+					http.Error(w, tc.body, tc.status)
+					return
+				}
+
+				json.NewEncoder(w).Encode(Result{ID: req.ID, Result: json.RawMessage(`3`)})
+			})
+
+			var a, b int64
+			batch := client.Batch()
+			callA := batch.LatestBlockHeight(&a)
+			callB := batch.GetBlockHeight(testBlockHash, &b)
+
+			err := batch.Send(context.Background())
+			if !tc.fallback {
+				if !errors.Is(err, ErrTransport) || requests != 1 {
+					t.Fatalf("got %v after %d requests want a transport error after 1", err, requests)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if callA.Error != nil || callB.Error != nil {
+				t.Fatal(callA.Error, callB.Error)
+			}
+
+			if a != 3 || b != 3 || requests != 3 {
+				t.Fatalf("got %d, %d in %d requests want 3, 3 in 3", a, b, requests)
+			}
+		})
+	}
+}
@@ -0,0 +1,491 @@
+package rpc
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"github.com/pinestreetlabs/aleo-wallet-sdk/ids"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// CacheConfig holds the configuration for a CachedClient.
+type CacheConfig struct {
+	// Size is the maximum number of entries kept in memory. Defaults to 1024.
+	Size int
+	// Dir enables an on-disk cache in the given directory when set.
+	// Entries on disk are never evicted.
+	Dir string
+	// Confirmations is the number of blocks, including its own, that must be on
+	// top of the chain before data from a block is cached. Defaults to 10.
+	Confirmations int64
+	// TipTTL is how long the latest block height is reused to compute confirmations. Defaults to 5s.
+	TipTTL time.Duration
+}
+
+// CachedClient wraps a Client and caches chain data once it is deep enough not to be reorged.
+//
+// Blocks, block headers and hashes are cached by height and hash, and transactions by ID.
+// Transitions and ciphertexts carry no height, so they are also cached when seen in a confirmed
+// transaction or block. Fetched directly, they are cached once no unconfirmed block contains them.
+// Methods that are not cached are passed to the underlying Client.
+type CachedClient struct {
+	*Client
+
+	cfg CacheConfig
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List
+
+	tipMu     sync.Mutex
+	tip       int64
+	tipExpiry time.Time
+
+	recentMu sync.Mutex
+	recent   *recentBlocks
+}
+
+// recentBlocks indexes the transitions and ciphertexts of the unconfirmed blocks below a tip by height.
+type recentBlocks struct {
+	tip         int64
+	transitions map[ids.TransitionID]int64
+	ciphertexts map[ids.CiphertextID]int64
+}
+
+type cacheEntry struct {
+	key   string
+	value []byte
+}
+
+// NewCachedClient returns a CachedClient wrapping client.
+func NewCachedClient(client *Client, cfg *CacheConfig) (*CachedClient, error) {
+	c := &CachedClient{
+		Client:  client,
+		cfg:     *cfg,
+		entries: make(map[string]*list.Element),
+		order:   list.New(),
+	}
+
+	if c.cfg.Size <= 0 {
+		c.cfg.Size = 1024
+	}
+
+	if c.cfg.Confirmations <= 0 {
+		c.cfg.Confirmations = 10
+	}
+
+	if c.cfg.TipTTL <= 0 {
+		c.cfg.TipTTL = 5 * time.Second
+	}
+
+	if c.cfg.Dir != "" {
+		if err := os.MkdirAll(c.cfg.Dir, 0700); err != nil {
+			return nil, err
+		}
+	}
+
+	return c, nil
+}
+
+// GetBlock returns information about a block from a block height.
+func (c *CachedClient) GetBlock(ctx context.Context, blockNumber int64) (*Block, error) {
+	var res Block
+	if c.get(blockKey(blockNumber), &res) {
+		return &res, nil
+	}
+
+	block, err := c.Client.GetBlock(ctx, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.confirmed(ctx, blockNumber) {
+		c.putBlock(block, blockNumber)
+	}
+
+	return block, nil
+}
+
+// GetBlockHeader returns the blockheader of a given block height.
+func (c *CachedClient) GetBlockHeader(ctx context.Context, height int64) (*BlockHeader, error) {
+	var res BlockHeader
+	if c.get(blockHeaderKey(height), &res) {
+		return &res, nil
+	}
+
+	header, err := c.Client.GetBlockHeader(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.confirmed(ctx, height) {
+		c.put(blockHeaderKey(height), header)
+	}
+
+	return header, nil
+}
+
+// GetBlockHash returns the block hash of a block at the given block height in the best valid chain.
+func (c *CachedClient) GetBlockHash(ctx context.Context, height int64) (ids.BlockHash, error) {
+	var res ids.BlockHash
+	if c.get(blockHashKey(height), &res) {
+		return res, nil
+	}
+
+	hash, err := c.Client.GetBlockHash(ctx, height)
+	if err != nil {
+		return "", err
+	}
+
+	if c.confirmed(ctx, height) {
+		c.put(blockHashKey(height), hash)
+		c.put(blockHeightKey(hash), height)
+	}
+
+	return hash, nil
+}
+
+// GetBlockHeight the block height for the given the block hash.
+func (c *CachedClient) GetBlockHeight(ctx context.Context, blockHash ids.BlockHash) (int64, error) {
+	var res int64
+	if c.get(blockHeightKey(blockHash), &res) {
+		return res, nil
+	}
+
+	height, err := c.Client.GetBlockHeight(ctx, blockHash)
+	if err != nil {
+		return 0, err
+	}
+
+	if c.confirmed(ctx, height) {
+		c.put(blockHeightKey(blockHash), height)
+		c.put(blockHashKey(height), blockHash)
+	}
+
+	return height, nil
+}
+
+// GetBlockByHash returns the block with the given hash.
+func (c *CachedClient) GetBlockByHash(ctx context.Context, blockHash ids.BlockHash) (*Block, error) {
+	return getBlockByHash(ctx, c, blockHash)
+}
+
+// GetTransaction returns a transaction with metadata given the transaction ID.
+func (c *CachedClient) GetTransaction(ctx context.Context, txID ids.TransactionID) (*GetTransactionResponse, error) {
+	var res GetTransactionResponse
+	if c.get(transactionKey(txID), &res) {
+		return &res, nil
+	}
+
+	tx, err := c.Client.GetTransaction(ctx, txID)
+	if err != nil {
+		return nil, err
+	}
+
+	if tx.Metadata.BlockHash != "" && c.confirmed(ctx, tx.Metadata.BlockHeight) {
+		c.putTransaction(tx)
+	}
+
+	return tx, nil
+}
+
+// GetTransition returns a transition given an id.
+func (c *CachedClient) GetTransition(ctx context.Context, transitionID ids.TransitionID) (*Transition, error) {
+	var res Transition
+	if c.get(transitionKey(transitionID), &res) {
+		return &res, nil
+	}
+
+	transition, err := c.Client.GetTransition(ctx, transitionID)
+	if err != nil {
+		return nil, err
+	}
+
+	lookup := func(r *recentBlocks) (int64, bool) {
+		height, ok := r.transitions[transitionID]
+		return height, ok
+	}
+	if height, ok := c.blockHeight(ctx, lookup); ok && c.confirmed(ctx, height) {
+		c.put(transitionKey(transitionID), transition)
+	}
+
+	return transition, nil
+}
+
+// GetCiphertext returns the ciphertext using a given id.
+func (c *CachedClient) GetCiphertext(ctx context.Context, id ids.CiphertextID) (string, error) {
+	var res string
+	if c.get(ciphertextKey(id), &res) {
+		return res, nil
+	}
+
+	ciphertext, err := c.Client.GetCiphertext(ctx, id)
+	if err != nil {
+		return "", err
+	}
+
+	lookup := func(r *recentBlocks) (int64, bool) {
+		height, ok := r.ciphertexts[id]
+		return height, ok
+	}
+	if height, ok := c.blockHeight(ctx, lookup); ok && c.confirmed(ctx, height) {
+		c.put(ciphertextKey(id), ciphertext)
+	}
+
+	return ciphertext, nil
+}
+
+// blockHeight returns the height of the block holding a transition or ciphertext the node has just returned,
+// found by lookup. They carry no height, so the blocks that are not yet confirmed are searched for it. If none holds it,
+// the height just below them is returned, since its block is at or under it. ok is false on errors.
+func (c *CachedClient) blockHeight(ctx context.Context, lookup func(*recentBlocks) (int64, bool)) (height int64, ok bool) {
+	// The tip is read after the transition so that its block is at or below the tip.
+	tip, err := c.Client.LatestBlockHeight(ctx)
+	if err != nil {
+		return 0, false
+	}
+	c.setTip(tip)
+
+	start := tip - c.cfg.Confirmations + 2
+	if start < 0 {
+		start = 0
+	}
+
+	if start <= tip {
+		recent, err := c.recentIndex(ctx, start, tip)
+		if err != nil {
+			return 0, false
+		}
+
+		if height, ok := lookup(recent); ok {
+			return height, true
+		}
+	}
+
+	return start - 1, start > 0
+}
+
+// recentIndex returns the index of the blocks from start to tip, fetching them once per tip height.
+// A reorg that keeps the height reuses a stale index, which is harmless since transitions and
+// ciphertexts are identified by their content.
+func (c *CachedClient) recentIndex(ctx context.Context, start, tip int64) (*recentBlocks, error) {
+	c.recentMu.Lock()
+	recent := c.recent
+	c.recentMu.Unlock()
+
+	if recent != nil && recent.tip == tip {
+		return recent, nil
+	}
+
+	blocks, err := c.Client.GetBlocks(ctx, start, tip)
+	if err != nil {
+		return nil, err
+	}
+
+	recent = &recentBlocks{
+		tip:         tip,
+		transitions: make(map[ids.TransitionID]int64),
+		ciphertexts: make(map[ids.CiphertextID]int64),
+	}
+	for i := range blocks {
+		for _, tx := range blocks[i].Transactions.Transactions {
+			for _, transition := range tx.Transitions {
+				recent.transitions[transition.ID] = start + int64(i)
+				for _, id := range transition.CiphertextIDs {
+					recent.ciphertexts[id] = start + int64(i)
+				}
+			}
+		}
+	}
+
+	c.recentMu.Lock()
+	c.recent = recent
+	c.recentMu.Unlock()
+
+	return recent, nil
+}
+
+// confirmed reports whether the block at height has enough confirmations to be cached.
+// Errors fetching the tip are treated as unconfirmed.
+func (c *CachedClient) confirmed(ctx context.Context, height int64) bool {
+	c.tipMu.Lock()
+	tip, expired := c.tip, time.Now().After(c.tipExpiry)
+	c.tipMu.Unlock()
+
+	// The tip is fetched without the lock so that a slow node does not hold up other readers.
+	if expired {
+		var err error
+		if tip, err = c.Client.LatestBlockHeight(ctx); err != nil {
+			return false
+		}
+		c.setTip(tip)
+	}
+
+	return tip-height+1 >= c.cfg.Confirmations
+}
+
+// setTip records the latest block height for TipTTL.
+func (c *CachedClient) setTip(tip int64) {
+	c.tipMu.Lock()
+	defer c.tipMu.Unlock()
+
+	c.tip = tip
+	c.tipExpiry = time.Now().Add(c.cfg.TipTTL)
+}
+
+// putBlock caches a confirmed block and everything it contains.
+func (c *CachedClient) putBlock(block *Block, height int64) {
+	c.put(blockKey(height), block)
+	c.put(blockHeaderKey(height), &block.BlockHeader)
+	c.put(blockHashKey(height), block.BlockHash)
+	c.put(blockHeightKey(block.BlockHash), height)
+
+	for i, tx := range block.Transactions.Transactions {
+		c.putTransaction(&GetTransactionResponse{
+			Transaction: tx,
+			Metadata: TransactionMetadata{
+				BlockHash:        block.BlockHash,
+				BlockHeight:      height,
+				BlockTimestamp:   block.BlockHeader.Metadata.Timestamp,
+				TransactionIndex: int64(i),
+			},
+		})
+	}
+}
+
+// putTransaction caches a confirmed transaction with its transitions and ciphertexts.
+func (c *CachedClient) putTransaction(tx *GetTransactionResponse) {
+	c.put(transactionKey(tx.Transaction.TxID), tx)
+
+	for i := range tx.Transaction.Transitions {
+		transition := &tx.Transaction.Transitions[i]
+		c.put(transitionKey(transition.ID), transition)
+
+		for j, id := range transition.CiphertextIDs {
+			if j < len(transition.Ciphertexts) {
+				c.put(ciphertextKey(id), transition.Ciphertexts[j])
+			}
+		}
+	}
+}
+
+// get decodes a cached entry into v, checking memory before disk.
+func (c *CachedClient) get(key string, v interface{}) bool {
+	c.mu.Lock()
+	elem, ok := c.entries[key]
+	if ok {
+		c.order.MoveToFront(elem)
+	}
+	c.mu.Unlock()
+
+	var buf []byte
+	if ok {
+		buf = elem.Value.(*cacheEntry).value
+	} else {
+		if c.cfg.Dir == "" {
+			return false
+		}
+
+		var err error
+		if buf, err = ioutil.ReadFile(c.path(key)); err != nil {
+			return false
+		}
+		c.store(key, buf)
+	}
+
+	return json.Unmarshal(buf, v) == nil
+}
+
+// put caches v in memory and, if enabled, on disk. Failures only skip caching.
+func (c *CachedClient) put(key string, v interface{}) {
+	buf, err := json.Marshal(v)
+	if err != nil {
+		return
+	}
+
+	c.store(key, buf)
+
+	if c.cfg.Dir != "" {
+		c.writeFile(key, buf)
+	}
+}
+
+// store adds an entry to the in-memory LRU, evicting the oldest entries past its size.
+func (c *CachedClient) store(key string, buf []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[key]; ok {
+		elem.Value.(*cacheEntry).value = buf
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&cacheEntry{key: key, value: buf})
+
+	for c.order.Len() > c.cfg.Size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).key)
+	}
+}
+
+// writeFile atomically writes an entry to the disk cache.
+func (c *CachedClient) writeFile(key string, buf []byte) {
+	tmp, err := ioutil.TempFile(c.cfg.Dir, ".tmp-")
+	if err != nil {
+		return
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(buf); err != nil {
+		tmp.Close()
+		return
+	}
+
+	if err := tmp.Close(); err != nil {
+		return
+	}
+
+	os.Rename(tmp.Name(), c.path(key))
+}
+
+func (c *CachedClient) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.cfg.Dir, hex.EncodeToString(sum[:]))
+}
+
+func blockKey(height int64) string {
+	return getBlockMethod + ":" + strconv.FormatInt(height, 10)
+}
+
+func blockHeaderKey(height int64) string {
+	return getBlockHeaderMethod + ":" + strconv.FormatInt(height, 10)
+}
+
+func blockHashKey(height int64) string {
+	return getBlockHashMethod + ":" + strconv.FormatInt(height, 10)
+}
+
+func blockHeightKey(hash ids.BlockHash) string {
+	return getBlockHeightMethod + ":" + string(hash)
+}
+
+func transactionKey(id ids.TransactionID) string {
+	return getTransactionMethod + ":" + string(id)
+}
+
+func transitionKey(id ids.TransitionID) string {
+	return getTransitionMethod + ":" + string(id)
+}
+
+func ciphertextKey(id ids.CiphertextID) string {
+	return getCiphertextMethod + ":" + string(id)
+}
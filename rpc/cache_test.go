@@ -0,0 +1,205 @@
+package rpc_test
+
+import (
+	"context"
+	"github.com/pinestreetlabs/aleo-wallet-sdk/ids"
+	"github.com/pinestreetlabs/aleo-wallet-sdk/rpc"
+	"github.com/pinestreetlabs/aleo-wallet-sdk/rpc/rpctest"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+)
+
+func newCachedClient(t *testing.T, s *rpctest.Server, dir string) *rpc.CachedClient {
+	t.Helper()
+
+	client, err := rpc.NewClient(s.Config())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cached, err := rpc.NewCachedClient(client, &rpc.CacheConfig{
+		Size:          16,
+		Dir:           dir,
+		Confirmations: 3,
+		TipTTL:        time.Nanosecond,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cached
+}
+
+func TestCachedClientConfirmations(t *testing.T) {
+	s := rpctest.NewServer()
+	defer s.Close()
+
+	tx := rpc.Transaction{
+		TxID: ids.TransactionID(rpctest.NewID("at", "tx")),
+		Transitions: []rpc.Transition{{
+			ID:            ids.TransitionID(rpctest.NewID("as", "transition")),
+			CiphertextIDs: []ids.CiphertextID{ids.CiphertextID(rpctest.NewID("ar", "ciphertext"))},
+			Ciphertexts:   []string{"deadbeef"},
+		}},
+	}
+
+	s.AddBlocks(1)
+	s.AddBlock(rpc.Block{Transactions: rpc.Transactions{Transactions: []rpc.Transaction{tx}}})
+
+	c := newCachedClient(t, s, "")
+	ctx := context.Background()
+
+	// The transaction only has one confirmation, so every call reaches the node.
+	for i := 0; i < 2; i++ {
+		if _, err := c.GetTransaction(ctx, tx.TxID); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := s.CallCount("gettransaction"); n != 2 {
+		t.Fatalf("got %d calls want %d", n, 2)
+	}
+
+	s.AddBlocks(2)
+
+	for i := 0; i < 2; i++ {
+		if _, err := c.GetTransaction(ctx, tx.TxID); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := s.CallCount("gettransaction"); n != 3 {
+		t.Fatalf("got %d calls want %d", n, 3)
+	}
+
+	// Transitions and ciphertexts of a confirmed transaction are cached with it.
+	if _, err := c.GetTransition(ctx, tx.Transitions[0].ID); err != nil {
+		t.Fatal(err)
+	}
+	ciphertext, err := c.GetCiphertext(ctx, tx.Transitions[0].CiphertextIDs[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ciphertext != "deadbeef" {
+		t.Fatalf("got %s want %s", ciphertext, "deadbeef")
+	}
+	if s.CallCount("gettransition") != 0 || s.CallCount("getciphertext") != 0 {
+		t.Fatal("expected cached transition and ciphertext")
+	}
+}
+
+func TestCachedClientDirect(t *testing.T) {
+	s := rpctest.NewServer()
+	defer s.Close()
+
+	transition := rpc.Transition{
+		ID:            ids.TransitionID(rpctest.NewID("as", "transition")),
+		CiphertextIDs: []ids.CiphertextID{ids.CiphertextID(rpctest.NewID("ar", "ciphertext"))},
+		Ciphertexts:   []string{"deadbeef"},
+	}
+	tx := rpc.Transaction{TxID: ids.TransactionID(rpctest.NewID("at", "tx")), Transitions: []rpc.Transition{transition}}
+
+	s.AddBlocks(3)
+	s.AddBlock(rpc.Block{Transactions: rpc.Transactions{Transactions: []rpc.Transaction{tx}}})
+
+	c := newCachedClient(t, s, "")
+	ctx := context.Background()
+
+	get := func() {
+		t.Helper()
+
+		if _, err := c.GetTransition(ctx, transition.ID); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := c.GetCiphertext(ctx, transition.CiphertextIDs[0]); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The block holding them only has one confirmation, so every call reaches the node.
+	get()
+	get()
+	if s.CallCount("gettransition") != 2 || s.CallCount("getciphertext") != 2 {
+		t.Fatalf("got %d and %d calls want 2", s.CallCount("gettransition"), s.CallCount("getciphertext"))
+	}
+
+	// The unconfirmed blocks are only fetched once per tip.
+	if n := s.CallCount("getblocks"); n != 1 {
+		t.Fatalf("got %d getblocks calls want 1", n)
+	}
+
+	s.AddBlocks(2)
+
+	get()
+	calls := len(s.Calls())
+	get()
+	if n := len(s.Calls()); n != calls {
+		t.Fatalf("got %d calls want %d", n, calls)
+	}
+	if s.CallCount("gettransition") != 3 || s.CallCount("getciphertext") != 3 || s.CallCount("getblocks") != 2 {
+		t.Fatalf("got %d, %d and %d calls want 3, 3 and 2", s.CallCount("gettransition"), s.CallCount("getciphertext"), s.CallCount("getblocks"))
+	}
+}
+
+func TestCachedClientBlocks(t *testing.T) {
+	s := rpctest.NewServer()
+	defer s.Close()
+
+	s.AddBlocks(10)
+	c := newCachedClient(t, s, "")
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		if _, err := c.GetBlock(ctx, 2); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := c.GetBlock(ctx, 9); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := s.CallCount("getblock"); n != 3 {
+		t.Fatalf("got %d calls want %d", n, 3)
+	}
+
+	// Headers and hashes come from the cached block.
+	header, err := c.GetBlockHeader(ctx, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if header.Metadata.Height != 2 {
+		t.Fatalf("got %d want %d", header.Metadata.Height, 2)
+	}
+	if s.CallCount("getblockheader") != 0 {
+		t.Fatal("expected cached header")
+	}
+}
+
+func TestCachedClientDisk(t *testing.T) {
+	s := rpctest.NewServer()
+	defer s.Close()
+
+	dir, err := ioutil.TempDir("", "rpccache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	s.AddBlocks(10)
+	ctx := context.Background()
+
+	want, err := newCachedClient(t, s, dir).GetBlockHash(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := newCachedClient(t, s, dir).GetBlockHash(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got != want {
+		t.Fatalf("got %s want %s", got, want)
+	}
+	if n := s.CallCount("getblockhash"); n != 1 {
+		t.Fatalf("got %d calls want %d", n, 1)
+	}
+}
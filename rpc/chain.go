@@ -0,0 +1,35 @@
+package rpc
+
+import (
+	"context"
+	"github.com/pinestreetlabs/aleo-wallet-sdk/ids"
+)
+
+// ChainClient is the chain access shared by the JSON-RPC and REST APIs of snarkOS.
+// It is implemented by Client, CachedClient, Pool and RESTClient.
+type ChainClient interface {
+	LatestBlockHeight(ctx context.Context) (int64, error)
+	LatestBlockHash(ctx context.Context) (ids.BlockHash, error)
+	LatestBlock(ctx context.Context) (*Block, error)
+	LatestBlockHeader(ctx context.Context) (*BlockHeader, error)
+	LatestBlockTransactions(ctx context.Context) (*Transactions, error)
+	GetBlock(ctx context.Context, blockNumber int64) (*Block, error)
+	GetBlockByHash(ctx context.Context, blockHash ids.BlockHash) (*Block, error)
+	GetBlocks(ctx context.Context, start, end int64) ([]Block, error)
+	GetBlockHash(ctx context.Context, height int64) (ids.BlockHash, error)
+	GetBlockHeight(ctx context.Context, blockHash ids.BlockHash) (int64, error)
+	GetBlockHeader(ctx context.Context, height int64) (*BlockHeader, error)
+	GetBlockTransactions(ctx context.Context, height int64) (*Transactions, error)
+	GetTransaction(ctx context.Context, txID ids.TransactionID) (*GetTransactionResponse, error)
+	GetTransition(ctx context.Context, transitionID ids.TransitionID) (*Transition, error)
+	SendTransaction(ctx context.Context, tx string) (ids.TransactionID, error)
+	GetConnectedPeers(ctx context.Context) ([]string, error)
+	GetMemoryPool(ctx context.Context) ([]Transaction, error)
+}
+
+var (
+	_ ChainClient = (*Client)(nil)
+	_ ChainClient = (*CachedClient)(nil)
+	_ ChainClient = (*Pool)(nil)
+	_ ChainClient = (*RESTClient)(nil)
+)
@@ -0,0 +1,151 @@
+package rpc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Error classes returned by the client. Use errors.Is to test an error against a class.
+var (
+	// ErrNotFound is returned when the requested block, transaction or record does not exist.
+	ErrNotFound = errors.New("not found")
+	// ErrInvalidParams is returned when the node rejects the request parameters.
+	ErrInvalidParams = errors.New("invalid params")
+	// ErrMethodNotFound is returned when the node does not support the requested method.
+	ErrMethodNotFound = errors.New("method not found")
+	// ErrInternal is returned when the node fails to process a valid request.
+	ErrInternal = errors.New("internal error")
+	// ErrTransport is returned when the node cannot be reached or answers with a non-2xx status.
+	ErrTransport = errors.New("transport error")
+	// ErrDecode is returned when a response cannot be decoded.
+	ErrDecode = errors.New("decode error")
+	// ErrResponseTooLarge is returned, wrapped in a TransportError, when a response exceeds Config.MaxResponseSize.
+	ErrResponseTooLarge = errors.New("response too large")
+)
+
+// JSON-RPC 2.0 error codes.
+const (
+	codeParseError     = -32700
+	codeInvalidRequest = -32600
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+	codeInternalError  = -32603
+)
+
+// Error implements the error interface for an RPC error response.
+func (e *Error) Error() string {
+	if len(e.Data) == 0 {
+		return fmt.Sprintf("rpc error %d : %s", e.Code, e.Message)
+	}
+	return fmt.Sprintf("rpc error %d : %s : %s", e.Code, e.Message, e.Data)
+}
+
+// Is reports whether the error belongs to the target error class.
+func (e *Error) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.notFound()
+	case ErrInvalidParams:
+		return e.Code == codeInvalidParams
+	case ErrMethodNotFound:
+		return e.Code == codeMethodNotFound
+	case ErrInternal:
+		return e.Code == codeInternalError && !e.notFound()
+	case ErrDecode:
+		return e.Code == codeParseError || e.Code == codeInvalidRequest
+	}
+	return false
+}
+
+// notFound reports whether the node failed because the requested object is missing.
+// snarkOS reports missing objects as internal or server errors, so the message is inspected.
+func (e *Error) notFound() bool {
+	switch e.Code {
+	case codeParseError, codeInvalidRequest, codeMethodNotFound, codeInvalidParams:
+		return false
+	}
+
+	msg := strings.ToLower(e.Message + " " + string(e.Data))
+	return strings.Contains(msg, "not found") || strings.Contains(msg, "does not exist")
+}
+
+// TransportError is returned when the request fails to reach the node.
+type TransportError struct {
+	Err error
+}
+
+// Error implements the error interface.
+func (e *TransportError) Error() string {
+	return fmt.Sprintf("rpc transport : %v", e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *TransportError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether the target is ErrTransport.
+func (e *TransportError) Is(target error) bool {
+	return target == ErrTransport
+}
+
+// HTTPError is returned when the node answers with a non-2xx HTTP status.
+// Body holds the start of the response.
+type HTTPError struct {
+	StatusCode int
+	Status     string
+	Body       []byte
+}
+
+// Error implements the error interface.
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("rpc http : %s : %s", e.Status, truncate(e.Body))
+}
+
+// Is reports whether the target is ErrTransport.
+func (e *HTTPError) Is(target error) bool {
+	return target == ErrTransport
+}
+
+// DecodeError is returned when a response body is not valid JSON-RPC or does not match the expected type.
+type DecodeError struct {
+	Body []byte
+	Err  error
+}
+
+// Error implements the error interface.
+func (e *DecodeError) Error() string {
+	if len(e.Body) == 0 {
+		return fmt.Sprintf("rpc decode : %v", e.Err)
+	}
+	return fmt.Sprintf("rpc decode : %v : %s", e.Err, truncate(e.Body))
+}
+
+// Unwrap returns the underlying error.
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether the target is ErrDecode.
+func (e *DecodeError) Is(target error) bool {
+	return target == ErrDecode
+}
+
+// decodeJSON unmarshals buf into v, returning a DecodeError on failure.
+func decodeJSON(buf []byte, v interface{}) error {
+	if err := json.Unmarshal(buf, v); err != nil {
+		return &DecodeError{Body: buf, Err: err}
+	}
+	return nil
+}
+
+// truncate shortens a response body for use in error messages.
+func truncate(body []byte) string {
+	const max = 256
+	if len(body) > max {
+		return string(body[:max]) + "..."
+	}
+	return string(body)
+}
@@ -0,0 +1,104 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestErrorClasses(t *testing.T) {
+	tests := []struct {
+		err    *Error
+		target error
+	}{
+		{&Error{Code: codeInvalidParams, Message: "Invalid params"}, ErrInvalidParams},
+		{&Error{Code: codeMethodNotFound, Message: "Method not found"}, ErrMethodNotFound},
+		{&Error{Code: codeInternalError, Message: "Internal error"}, ErrInternal},
+		{&Error{Code: codeInternalError, Message: "transaction at1abc does not exist"}, ErrNotFound},
+		{&Error{Code: -32000, Message: "block not found"}, ErrNotFound},
+		{&Error{Code: codeParseError, Message: "Parse error"}, ErrDecode},
+	}
+
+	for _, tc := range tests {
+		if !errors.Is(tc.err, tc.target) {
+			t.Fatalf("%v : expected %v", tc.err, tc.target)
+		}
+	}
+
+	notFound := &Error{Code: codeInternalError, Message: "transaction not found"}
+	if errors.Is(notFound, ErrInternal) {
+		t.Fatalf("%v : unexpected %v", notFound, ErrInternal)
+	}
+
+	invalid := &Error{Code: codeInvalidParams, Message: "field not found"}
+	if errors.Is(invalid, ErrNotFound) {
+		t.Fatalf("%v : unexpected %v", invalid, ErrNotFound)
+	}
+}
+
+func TestRPCError(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"jsonrpc":"2.0","error":{"code":-32602,"message":"Invalid params","data":"expected string"},"id":"1"}`))
+	})
+
+	_, err := client.GetTransaction(context.Background(), "at1abc")
+	if !errors.Is(err, ErrInvalidParams) {
+		t.Fatalf("got %v want %v", err, ErrInvalidParams)
+	}
+
+	var rpcErr *Error
+	if !errors.As(err, &rpcErr) {
+		t.Fatalf("got %T want %T", err, rpcErr)
+	}
+
+	if string(rpcErr.Data) != `"expected string"` {
+		t.Fatalf("got %s want %s", rpcErr.Data, `"expected string"`)
+	}
+}
+
+func TestHTTPError(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "bad gateway", http.StatusBadGateway)
+	})
+
+	_, err := client.LatestBlockHeight(context.Background())
+	if !errors.Is(err, ErrTransport) {
+		t.Fatalf("got %v want %v", err, ErrTransport)
+	}
+
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusBadGateway {
+		t.Fatalf("got %v want status %d", err, http.StatusBadGateway)
+	}
+}
+
+func TestDecodeError(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<html>not json</html>`))
+	})
+
+	_, err := client.LatestBlockHeight(context.Background())
+	if !errors.Is(err, ErrDecode) {
+		t.Fatalf("got %v want %v", err, ErrDecode)
+	}
+
+	client = newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"jsonrpc":"2.0","result":"ab1hash","id":"1"}`))
+	})
+
+	if _, err := client.LatestBlockHeight(context.Background()); !errors.Is(err, ErrDecode) {
+		t.Fatalf("got %v want %v", err, ErrDecode)
+	}
+}
+
+func TestTransportError(t *testing.T) {
+	client, err := NewClient(&Config{Host: "127.0.0.1", Port: "1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.LatestBlockHeight(context.Background()); !errors.Is(err, ErrTransport) {
+		t.Fatalf("got %v want %v", err, ErrTransport)
+	}
+}
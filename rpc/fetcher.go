@@ -0,0 +1,236 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+var errShortChunk = errors.New("node returned an incomplete block range")
+var errChunkHeight = errors.New("node returned a block at the wrong height")
+
+// RangeSource is the subset of the client used to fetch block ranges.
+// It is implemented by Client and Pool.
+type RangeSource interface {
+	GetBlocks(ctx context.Context, start, end int64) ([]Block, error)
+}
+
+// FetcherConfig holds the configuration for a RangeFetcher.
+type FetcherConfig struct {
+	// ChunkSize is the number of blocks requested per getblocks call. Defaults to 50.
+	ChunkSize int64
+	// Concurrency is the maximum number of chunks fetched at once. Defaults to 4.
+	Concurrency int
+	// RequestsPerSecond limits the rate of getblocks calls. Zero means unlimited.
+	RequestsPerSecond float64
+	// OnProgress is called after each chunk is delivered.
+	OnProgress func(FetchProgress)
+	// Validator, when set, checks each block against the previous one before it is delivered.
+	Validator *HeaderValidator
+}
+
+// FetchProgress reports how far a Fetch has gone.
+type FetchProgress struct {
+	Start     int64
+	End       int64
+	Height    int64
+	Delivered int64
+}
+
+// FetchError is returned when a chunk cannot be fetched.
+// Every block below Height was delivered, so the fetch can be resumed from Height.
+type FetchError struct {
+	Height int64
+	Err    error
+}
+
+// Error implements the error interface.
+func (e *FetchError) Error() string {
+	return fmt.Sprintf("fetch from height %d : %v", e.Height, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *FetchError) Unwrap() error {
+	return e.Err
+}
+
+// RangeFetcher fetches large block ranges in parallel chunks and delivers them in height order.
+type RangeFetcher struct {
+	src     RangeSource
+	cfg     FetcherConfig
+	limiter *limiter
+}
+
+// NewRangeFetcher returns a RangeFetcher reading blocks from src.
+func NewRangeFetcher(src RangeSource, cfg *FetcherConfig) *RangeFetcher {
+	f := &RangeFetcher{src: src, cfg: *cfg}
+
+	if f.cfg.ChunkSize <= 0 {
+		f.cfg.ChunkSize = 50
+	}
+
+	if f.cfg.Concurrency <= 0 {
+		f.cfg.Concurrency = 4
+	}
+
+	if f.cfg.RequestsPerSecond > 0 {
+		f.limiter = &limiter{interval: time.Duration(float64(time.Second) / f.cfg.RequestsPerSecond)}
+	}
+
+	return f
+}
+
+type chunk struct {
+	start, end int64
+}
+
+type chunkResult struct {
+	index  int
+	blocks []Block
+	err    error
+}
+
+// Fetch delivers every block from start to end, inclusive, to out in height order.
+// It returns once all blocks are delivered, the context is done, or a chunk fails,
+// in which case the error is a *FetchError holding the height to resume from.
+// Fetch does not close out.
+func (f *RangeFetcher) Fetch(ctx context.Context, start, end int64, out chan<- Block) error {
+	if start > end {
+		return errors.New("start > end")
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var chunks []chunk
+	for s := start; s <= end; s += f.cfg.ChunkSize {
+		e := s + f.cfg.ChunkSize - 1
+		if e > end {
+			e = end
+		}
+		chunks = append(chunks, chunk{start: s, end: e})
+	}
+
+	// Fetched chunks wait for delivery in pending, so at most window chunks are held at once.
+	window := 2 * f.cfg.Concurrency
+	results := make(chan chunkResult, window)
+	pending := make(map[int]chunkResult)
+
+	var next, launched, inflight int
+	var delivered int64
+	var parent *Block
+
+	for next < len(chunks) {
+		for launched < len(chunks) && launched-next < window && inflight < f.cfg.Concurrency {
+			go f.fetchChunk(ctx, launched, chunks[launched], results)
+			launched++
+			inflight++
+		}
+
+		select {
+		case res := <-results:
+			inflight--
+			pending[res.index] = res
+		case <-ctx.Done():
+			return &FetchError{Height: chunks[next].start, Err: ctx.Err()}
+		}
+
+		for {
+			res, ok := pending[next]
+			if !ok {
+				break
+			}
+			delete(pending, next)
+
+			if res.err != nil {
+				return &FetchError{Height: chunks[next].start, Err: res.err}
+			}
+
+			for i := range res.blocks {
+				block := &res.blocks[i]
+				if f.cfg.Validator != nil {
+					if err := f.cfg.Validator.CheckBlock(parent, block); err != nil {
+						return &FetchError{Height: chunks[next].start + int64(i), Err: err}
+					}
+				}
+				parent = block
+
+				select {
+				case out <- *block:
+				case <-ctx.Done():
+					return &FetchError{Height: chunks[next].start + int64(i), Err: ctx.Err()}
+				}
+			}
+			delivered += int64(len(res.blocks))
+
+			if f.cfg.OnProgress != nil {
+				f.cfg.OnProgress(FetchProgress{
+					Start:     start,
+					End:       end,
+					Height:    chunks[next].end,
+					Delivered: delivered,
+				})
+			}
+			next++
+		}
+	}
+
+	return nil
+}
+
+// fetchChunk fetches one chunk and sends exactly one result.
+func (f *RangeFetcher) fetchChunk(ctx context.Context, index int, c chunk, results chan<- chunkResult) {
+	res := chunkResult{index: index}
+
+	if f.limiter != nil {
+		if res.err = f.limiter.wait(ctx); res.err != nil {
+			results <- res
+			return
+		}
+	}
+
+	res.blocks, res.err = f.src.GetBlocks(ctx, c.start, c.end)
+	if res.err == nil && int64(len(res.blocks)) != c.end-c.start+1 {
+		res.err = fmt.Errorf("%w : got %d blocks for %d-%d", errShortChunk, len(res.blocks), c.start, c.end)
+	}
+
+	for i := 0; res.err == nil && i < len(res.blocks); i++ {
+		if height := res.blocks[i].BlockHeader.Metadata.Height; height != c.start+int64(i) {
+			res.err = fmt.Errorf("%w : got %d want %d", errChunkHeight, height, c.start+int64(i))
+		}
+	}
+
+	results <- res
+}
+
+// limiter spaces calls at least interval apart.
+type limiter struct {
+	interval time.Duration
+
+	mu   sync.Mutex
+	next time.Time
+}
+
+// wait blocks until the caller's turn or the context is done.
+func (l *limiter) wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	at := l.next
+	if at.Before(now) {
+		at = now
+	}
+	l.next = at.Add(l.interval)
+	l.mu.Unlock()
+
+	timer := time.NewTimer(at.Sub(now))
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
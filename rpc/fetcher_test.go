@@ -0,0 +1,188 @@
+package rpc_test
+
+import (
+	"context"
+	"errors"
+	"github.com/pinestreetlabs/aleo-wallet-sdk/rpc"
+	"github.com/pinestreetlabs/aleo-wallet-sdk/rpc/rpctest"
+	"testing"
+	"time"
+)
+
+// collect runs Fetch and returns the heights it delivered.
+func collect(f *rpc.RangeFetcher, start, end int64) ([]int64, error) {
+	out := make(chan rpc.Block)
+	errc := make(chan error, 1)
+	go func() {
+		errc <- f.Fetch(context.Background(), start, end, out)
+		close(out)
+	}()
+
+	var heights []int64
+	for block := range out {
+		heights = append(heights, block.BlockHeader.Metadata.Height)
+	}
+	return heights, <-errc
+}
+
+func TestRangeFetcher(t *testing.T) {
+	s := rpctest.NewServer()
+	defer s.Close()
+
+	s.AddBlocks(100)
+	s.SetLatency("getblocks", time.Millisecond)
+
+	client, err := rpc.NewClient(s.Config())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var progress []rpc.FetchProgress
+	f := rpc.NewRangeFetcher(client, &rpc.FetcherConfig{
+		ChunkSize:   7,
+		Concurrency: 4,
+		OnProgress:  func(p rpc.FetchProgress) { progress = append(progress, p) },
+	})
+
+	heights, err := collect(f, 3, 92)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(heights) != 90 {
+		t.Fatalf("got %d blocks want %d", len(heights), 90)
+	}
+	for i, h := range heights {
+		if h != int64(i)+3 {
+			t.Fatalf("got height %d at %d want %d", h, i, i+3)
+		}
+	}
+
+	if n := s.CallCount("getblocks"); n != 13 {
+		t.Fatalf("got %d calls want %d", n, 13)
+	}
+
+	last := progress[len(progress)-1]
+	if last.Height != 92 || last.Delivered != 90 {
+		t.Fatalf("unexpected progress %+v", last)
+	}
+}
+
+func TestRangeFetcherResume(t *testing.T) {
+	s := rpctest.NewServer()
+	defer s.Close()
+
+	s.AddBlocks(20)
+
+	client, err := rpc.NewClient(s.Config())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f := rpc.NewRangeFetcher(client, &rpc.FetcherConfig{ChunkSize: 5, Concurrency: 2})
+
+	// The node only has blocks up to 19, so the chunk starting at 20 fails.
+	heights, err := collect(f, 0, 24)
+
+	var fetchErr *rpc.FetchError
+	if !errors.As(err, &fetchErr) || !errors.Is(err, rpc.ErrNotFound) {
+		t.Fatalf("got %v want %T", err, fetchErr)
+	}
+	if fetchErr.Height != 20 || len(heights) != 20 {
+		t.Fatalf("got resume height %d after %d blocks want 20 after 20", fetchErr.Height, len(heights))
+	}
+
+	s.AddBlocks(5)
+
+	heights, err = collect(f, fetchErr.Height, 24)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(heights) != 5 || heights[0] != 20 {
+		t.Fatalf("unexpected heights %v", heights)
+	}
+}
+
+func TestRangeFetcherValidator(t *testing.T) {
+	s := rpctest.NewServer()
+	defer s.Close()
+
+	s.AddBlocks(5)
+	// The block at height 5 claims to be older than its parent.
+	s.AddBlock(rpc.Block{BlockHeader: rpc.BlockHeader{Metadata: rpc.BlockHeaderMetadata{Timestamp: 1}}})
+	s.AddBlocks(4)
+
+	client, err := rpc.NewClient(s.Config())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f := rpc.NewRangeFetcher(client, &rpc.FetcherConfig{
+		ChunkSize: 3,
+		Validator: rpc.NewHeaderValidator(&rpc.ValidatorConfig{}),
+	})
+
+	heights, err := collect(f, 0, 9)
+
+	var fetchErr *rpc.FetchError
+	if !errors.As(err, &fetchErr) || !errors.Is(err, rpc.ErrInvalidBlock) {
+		t.Fatalf("got %v want %v", err, rpc.ErrInvalidBlock)
+	}
+	if fetchErr.Height != 5 || len(heights) != 5 {
+		t.Fatalf("got resume height %d after %d blocks want 5 after 5", fetchErr.Height, len(heights))
+	}
+}
+
+func TestRangeFetcherRateLimit(t *testing.T) {
+	s := rpctest.NewServer()
+	defer s.Close()
+
+	s.AddBlocks(10)
+
+	client, err := rpc.NewClient(s.Config())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f := rpc.NewRangeFetcher(client, &rpc.FetcherConfig{ChunkSize: 2, Concurrency: 5, RequestsPerSecond: 100})
+
+	begin := time.Now()
+	if _, err := collect(f, 0, 9); err != nil {
+		t.Fatal(err)
+	}
+
+	// Five calls spaced 10ms apart take at least 40ms.
+	if elapsed := time.Since(begin); elapsed < 40*time.Millisecond {
+		t.Fatalf("got %v want at least %v", elapsed, 40*time.Millisecond)
+	}
+}
+
+// shiftedSource returns the blocks after the requested ones, like a node that is off by one.
+type shiftedSource struct {
+	*rpc.Client
+}
+
+func (s shiftedSource) GetBlocks(ctx context.Context, start, end int64) ([]rpc.Block, error) {
+	return s.Client.GetBlocks(ctx, start+1, end+1)
+}
+
+func TestRangeFetcherHeights(t *testing.T) {
+	s := rpctest.NewServer()
+	defer s.Close()
+
+	s.AddBlocks(10)
+
+	client, err := rpc.NewClient(s.Config())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f := rpc.NewRangeFetcher(shiftedSource{client}, &rpc.FetcherConfig{ChunkSize: 3})
+
+	heights, err := collect(f, 0, 5)
+
+	var fetchErr *rpc.FetchError
+	if !errors.As(err, &fetchErr) || fetchErr.Height != 0 || len(heights) != 0 {
+		t.Fatalf("got %v after %d blocks want a FetchError at 0 after 0", err, len(heights))
+	}
+}
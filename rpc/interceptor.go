@@ -0,0 +1,200 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"regexp"
+	"sync"
+	"time"
+)
+
+// BatchMethod is the method name interceptors see for a batch request.
+// Its params are the serialized requests and its result is the raw response array.
+const BatchMethod = "batch"
+
+// Invoker sends a call and returns its raw result.
+type Invoker func(ctx context.Context, method string, params []json.RawMessage) (json.RawMessage, error)
+
+// Interceptor wraps a call. It may inspect or modify the call, and must call next to send it.
+type Interceptor func(ctx context.Context, method string, params []json.RawMessage, next Invoker) (json.RawMessage, error)
+
+// chainInterceptors wraps final so the first interceptor runs outermost.
+func chainInterceptors(interceptors []Interceptor, final Invoker) Invoker {
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor, next := interceptors[i], final
+		final = func(ctx context.Context, method string, params []json.RawMessage) (json.RawMessage, error) {
+			return interceptor(ctx, method, params, next)
+		}
+	}
+	return final
+}
+
+// secretPattern matches base58 encoded private keys and view keys, which must never be logged.
+var secretPattern = regexp.MustCompile(`(APrivateKey1|AViewKey1)[1-9A-HJ-NP-Za-km-z]*`)
+
+// LogConfig holds the configuration for LogInterceptor.
+type LogConfig struct {
+	// Writer receives one JSON object per call.
+	Writer io.Writer
+	// Results includes the raw result of successful calls.
+	Results bool
+	// MaxValueLen truncates long strings such as serialized transactions. Defaults to 128.
+	MaxValueLen int
+}
+
+// logEntry is a single structured log line.
+type logEntry struct {
+	Time      time.Time         `json:"time"`
+	Method    string            `json:"method"`
+	Params    []json.RawMessage `json:"params,omitempty"`
+	Result    json.RawMessage   `json:"result,omitempty"`
+	Error     string            `json:"error,omitempty"`
+	LatencyMS float64           `json:"latency_ms"`
+}
+
+// LogInterceptor returns an Interceptor that writes a JSON line per call.
+// Private keys and view keys are redacted and long strings are truncated.
+func LogInterceptor(cfg *LogConfig) Interceptor {
+	maxLen := cfg.MaxValueLen
+	if maxLen <= 0 {
+		maxLen = 128
+	}
+
+	var mu sync.Mutex
+	return func(ctx context.Context, method string, params []json.RawMessage, next Invoker) (json.RawMessage, error) {
+		start := time.Now()
+		result, err := next(ctx, method, params)
+
+		entry := logEntry{
+			Time:      start.UTC(),
+			Method:    method,
+			LatencyMS: float64(time.Since(start)) / float64(time.Millisecond),
+		}
+
+		for _, param := range params {
+			entry.Params = append(entry.Params, redact(param, maxLen))
+		}
+
+		if err != nil {
+			entry.Error = secretPattern.ReplaceAllString(err.Error(), redacted)
+		} else if cfg.Results {
+			entry.Result = redact(result, maxLen)
+		}
+
+		if buf, marshalErr := json.Marshal(entry); marshalErr == nil {
+			mu.Lock()
+			cfg.Writer.Write(append(buf, '\n'))
+			mu.Unlock()
+		}
+
+		return result, err
+	}
+}
+
+const redacted = "[REDACTED]"
+
+// redact replaces secrets and truncates long strings anywhere in a JSON value.
+func redact(raw json.RawMessage, maxLen int) json.RawMessage {
+	var v interface{}
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return json.RawMessage(`"[unparsable]"`)
+	}
+
+	buf, err := json.Marshal(redactValue(v, maxLen))
+	if err != nil {
+		return json.RawMessage(`"[unparsable]"`)
+	}
+	return buf
+}
+
+func redactValue(v interface{}, maxLen int) interface{} {
+	switch val := v.(type) {
+	case string:
+		val = secretPattern.ReplaceAllString(val, redacted)
+		if len(val) > maxLen {
+			return val[:maxLen] + "..."
+		}
+		return val
+	case []interface{}:
+		for i := range val {
+			val[i] = redactValue(val[i], maxLen)
+		}
+		return val
+	case map[string]interface{}:
+		for k := range val {
+			val[k] = redactValue(val[k], maxLen)
+		}
+		return val
+	}
+	return v
+}
+
+// MethodStats holds the counters for one method.
+type MethodStats struct {
+	Calls        int64
+	Errors       int64
+	TotalLatency time.Duration
+	MaxLatency   time.Duration
+}
+
+// MeanLatency returns the average latency of the calls.
+func (s MethodStats) MeanLatency() time.Duration {
+	if s.Calls == 0 {
+		return 0
+	}
+	return s.TotalLatency / time.Duration(s.Calls)
+}
+
+// Metrics counts calls, errors and latency per method.
+type Metrics struct {
+	mu    sync.Mutex
+	stats map[string]*MethodStats
+}
+
+// NewMetrics returns an empty Metrics.
+func NewMetrics() *Metrics {
+	return &Metrics{stats: make(map[string]*MethodStats)}
+}
+
+// Interceptor returns an Interceptor that records every call in m.
+func (m *Metrics) Interceptor() Interceptor {
+	return func(ctx context.Context, method string, params []json.RawMessage, next Invoker) (json.RawMessage, error) {
+		start := time.Now()
+		result, err := next(ctx, method, params)
+		m.observe(method, time.Since(start), err)
+		return result, err
+	}
+}
+
+func (m *Metrics) observe(method string, latency time.Duration, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stats, ok := m.stats[method]
+	if !ok {
+		stats = &MethodStats{}
+		m.stats[method] = stats
+	}
+
+	stats.Calls++
+	stats.TotalLatency += latency
+	if latency > stats.MaxLatency {
+		stats.MaxLatency = latency
+	}
+	if err != nil {
+		stats.Errors++
+	}
+}
+
+// Snapshot returns a copy of the counters for every method seen so far.
+func (m *Metrics) Snapshot() map[string]MethodStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	res := make(map[string]MethodStats, len(m.stats))
+	for method, stats := range m.stats {
+		res[method] = *stats
+	}
+	return res
+}
@@ -0,0 +1,125 @@
+package rpc_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/pinestreetlabs/aleo-wallet-sdk/ids"
+	"github.com/pinestreetlabs/aleo-wallet-sdk/rpc"
+	"github.com/pinestreetlabs/aleo-wallet-sdk/rpc/rpctest"
+	"strings"
+	"testing"
+)
+
+func TestInterceptorOrder(t *testing.T) {
+	s := rpctest.NewServer()
+	defer s.Close()
+
+	s.AddBlocks(1)
+
+	var order []string
+	tag := func(name string) rpc.Interceptor {
+		return func(ctx context.Context, method string, params []json.RawMessage, next rpc.Invoker) (json.RawMessage, error) {
+			order = append(order, name+":"+method)
+			return next(ctx, method, params)
+		}
+	}
+
+	cfg := s.Config()
+	cfg.Interceptors = []rpc.Interceptor{tag("outer"), tag("inner")}
+
+	client, err := rpc.NewClient(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.LatestBlockHeight(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := strings.Join(order, ","); got != "outer:latestblockheight,inner:latestblockheight" {
+		t.Fatalf("got %s", got)
+	}
+}
+
+func TestLogInterceptor(t *testing.T) {
+	s := rpctest.NewServer()
+	defer s.Close()
+
+	var buf bytes.Buffer
+	cfg := s.Config()
+	cfg.Interceptors = []rpc.Interceptor{rpc.LogInterceptor(&rpc.LogConfig{Writer: &buf, Results: true, MaxValueLen: 16})}
+
+	client, err := rpc.NewClient(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	secret := "APrivateKey1zkp8cC4jgHEBnbtu3xxs1Ndja2EMizcvTRDq5Nikdkukg1p"
+	client.GetCiphertext(context.Background(), ids.CiphertextID(secret))
+	client.SendTransaction(context.Background(), strings.Repeat("ab", 64))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines want %d", len(lines), 2)
+	}
+
+	if strings.Contains(buf.String(), secret) {
+		t.Fatalf("secret logged : %s", lines[0])
+	}
+
+	var entry struct {
+		Method string   `json:"method"`
+		Params []string `json:"params"`
+		Error  string   `json:"error"`
+		Result string   `json:"result"`
+	}
+	if err := json.Unmarshal([]byte(lines[0]), &entry); err != nil {
+		t.Fatal(err)
+	}
+	if entry.Method != "getciphertext" || entry.Params[0] != "[REDACTED]" || entry.Error == "" {
+		t.Fatalf("unexpected entry %s", lines[0])
+	}
+
+	if err := json.Unmarshal([]byte(lines[1]), &entry); err != nil {
+		t.Fatal(err)
+	}
+	if entry.Params[0] != strings.Repeat("ab", 8)+"..." || entry.Result == "" {
+		t.Fatalf("unexpected entry %s", lines[1])
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	s := rpctest.NewServer()
+	defer s.Close()
+
+	s.AddBlocks(1)
+
+	metrics := rpc.NewMetrics()
+	cfg := s.Config()
+	cfg.Interceptors = []rpc.Interceptor{metrics.Interceptor()}
+
+	client, err := rpc.NewClient(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	client.GetBlock(ctx, 0)
+	client.GetBlock(ctx, 5)
+
+	batch := client.Batch()
+	batch.LatestBlockHeight(new(int64))
+	batch.LatestBlockHash(new(ids.BlockHash))
+	if err := batch.Send(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	snapshot := metrics.Snapshot()
+	if stats := snapshot["getblock"]; stats.Calls != 2 || stats.Errors != 1 {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+	if stats := snapshot[rpc.BatchMethod]; stats.Calls != 1 || stats.Errors != 0 {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+}
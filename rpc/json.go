@@ -2,6 +2,7 @@ package rpc
 
 import (
 	"encoding/json"
+	"github.com/pinestreetlabs/aleo-wallet-sdk/ids"
 	"math/big"
 )
 
@@ -15,9 +16,9 @@ type Request struct {
 
 // Error is the RPC error response.
 type Error struct {
-	Code    int    `json:"code"`
-	Message string `json:"message"`
-	Data    string `json:"data"`
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data,omitempty"`
 }
 
 // Result is the RPC result response.
@@ -34,15 +35,15 @@ type Transactions struct {
 
 // Block contains the block response object.
 type Block struct {
-	BlockHash         string       `json:"block_hash"`
-	PreviousBlockHash string       `json:"previous_block_hash"`
-	Transactions      Transactions `json:"transactions"`
-	BlockHeader       BlockHeader  `json:"header"`
+	BlockHash         ids.BlockHash `json:"block_hash"`
+	PreviousBlockHash ids.BlockHash `json:"previous_block_hash"`
+	Transactions      Transactions  `json:"transactions"`
+	BlockHeader       BlockHeader   `json:"header"`
 }
 
 // BlockHeader contains the blockheader response object.
 type BlockHeader struct {
-	PrevLedgerRoot   string              `json:"previous_ledger_root"`
+	PrevLedgerRoot   ids.LedgerRoot      `json:"previous_ledger_root"`
 	TransactionsRoot string              `json:"transactions_root"`
 	Proof            string              `json:"string"`
 	Metadata         BlockHeaderMetadata `json:"metadata"`
@@ -64,27 +65,57 @@ type GetTransactionResponse struct {
 
 // TransactionMetadata contains the metadata for a transaction object.
 type TransactionMetadata struct {
-	BlockHash        string `json:"block_hash"`
-	BlockHeight      int64  `json:"block_height"`
-	BlockTimestamp   int64  `json:"block_timestamp"`
-	TransactionIndex int64  `json:"transaction_index"`
+	BlockHash        ids.BlockHash `json:"block_hash"`
+	BlockHeight      int64         `json:"block_height"`
+	BlockTimestamp   int64         `json:"block_timestamp"`
+	TransactionIndex int64         `json:"transaction_index"`
 }
 
 // Transaction is the transaction object.
 type Transaction struct {
-	TxID           string       `json:"transaction_id"`
-	LedgerRoot     string       `json:"ledger_root"`
-	InnerCircuitID string       `json:"inner_circuit_id"`
-	Transitions    []Transition `json:"transitions"`
+	TxID           ids.TransactionID `json:"transaction_id"`
+	LedgerRoot     ids.LedgerRoot    `json:"ledger_root"`
+	InnerCircuitID string            `json:"inner_circuit_id"`
+	Transitions    []Transition      `json:"transitions"`
 }
 
 // Transition is a transition object.
 type Transition struct {
-	CiphertextIDs []string `json:"ciphertext_ids"`
-	Ciphertexts   []string `json:"ciphertexts"`
-	Commitments   []string `json:"commitments"`
-	Proof         string   `json:"proof"`
-	SerialNumbers []string `json:"serial_numbers"`
-	ID            string   `json:"transition_id"`
-	ValueBalance  int64    `json:"value_balance"`
+	CiphertextIDs []ids.CiphertextID `json:"ciphertext_ids"`
+	Ciphertexts   []string           `json:"ciphertexts"`
+	Commitments   []ids.Commitment   `json:"commitments"`
+	Proof         string             `json:"proof"`
+	SerialNumbers []ids.SerialNumber `json:"serial_numbers"`
+	ID            ids.TransitionID   `json:"transition_id"`
+	ValueBalance  int64              `json:"value_balance"`
+}
+
+// BlockTemplate is the template for the next block to mine, as returned by getblocktemplate.
+type BlockTemplate struct {
+	PreviousBlockHash ids.BlockHash  `json:"previous_block_hash"`
+	BlockHeight       int64          `json:"block_height"`
+	Time              int64          `json:"time"`
+	DifficultyTarget  uint64         `json:"difficulty_target"`
+	CumulativeWeight  *big.Int       `json:"cumulative_weight"`
+	LedgerRoot        ids.LedgerRoot `json:"ledger_root"`
+	Transactions      []Transaction  `json:"transactions"`
+	// CoinbaseRecord is the record paying the miner, in the node's own encoding.
+	CoinbaseRecord json.RawMessage `json:"coinbase_record"`
+}
+
+// NodeState is the state of a node, as returned by getnodestate.
+type NodeState struct {
+	CandidatePeers             []string      `json:"candidate_peers"`
+	ConnectedPeers             []string      `json:"connected_peers"`
+	LatestBlockHash            ids.BlockHash `json:"latest_block_hash"`
+	LatestBlockHeight          int64         `json:"latest_block_height"`
+	LatestCumulativeWeight     *big.Int      `json:"latest_cumulative_weight"`
+	Launched                   string        `json:"launched"`
+	NumberOfCandidatePeers     int           `json:"number_of_candidate_peers"`
+	NumberOfConnectedPeers     int           `json:"number_of_connected_peers"`
+	NumberOfConnectedSyncNodes int           `json:"number_of_connected_sync_nodes"`
+	Software                   string        `json:"software"`
+	Status                     string        `json:"status"`
+	Type                       string        `json:"type"`
+	Version                    int           `json:"version"`
 }
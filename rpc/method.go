@@ -22,4 +22,35 @@ const (
 	latestBlockHeightMethod       = "latestblockheight"
 	latestBlockTransactionsMethod = "latestblocktransactions"
 	getConnectedPeersMethod       = "getconnectedpeers"
+	latestCumulativeWeightMethod  = "latestcumulativeweight"
+	getBlockTemplateMethod        = "getblocktemplate"
+	getMemoryPoolMethod           = "getmemorypool"
+	getNodeStateMethod            = "getnodestate"
 )
+
+// readMethods are the supported methods that only read chain or node state.
+var readMethods = map[string]bool{
+	getBestBlockHashMethod:        true,
+	getBlockMethod:                true,
+	getBlockHeightMethod:          true,
+	getBlockHashMethod:            true,
+	getTransactionMethod:          true,
+	getTransitionMethod:           true,
+	latestLedgerRootMethod:        true,
+	getLedgerProofMethod:          true,
+	getCiphertextMethod:           true,
+	getBlockHashesMethod:          true,
+	getBlockHeaderMethod:          true,
+	getBlocksMethod:               true,
+	getBlockTransactionsMethod:    true,
+	latestBlockMethod:             true,
+	latestBlockHashMethod:         true,
+	latestBlockHeaderMethod:       true,
+	latestBlockHeightMethod:       true,
+	latestBlockTransactionsMethod: true,
+	getConnectedPeersMethod:       true,
+	latestCumulativeWeightMethod:  true,
+	getBlockTemplateMethod:        true,
+	getMemoryPoolMethod:           true,
+	getNodeStateMethod:            true,
+}
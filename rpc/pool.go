@@ -0,0 +1,506 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/pinestreetlabs/aleo-wallet-sdk/ids"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+)
+
+var errNoNodes = errors.New("no nodes configured")
+
+// PoolConfig holds the configuration for a Pool.
+type PoolConfig struct {
+	// Nodes holds one client configuration per snarkOS node.
+	Nodes []*Config
+	// HealthCheckInterval is the delay between health checks in Run. Defaults to 10s.
+	HealthCheckInterval time.Duration
+}
+
+// NodeStatus is the last known state of a node in a Pool.
+type NodeStatus struct {
+	URL     string
+	Healthy bool
+	Height  int64
+	Err     error
+}
+
+// Pool routes calls across several snarkOS nodes.
+// Reads go to the most up-to-date healthy node and fail over to the next one on error.
+// Transactions are broadcast to every node.
+type Pool struct {
+	cfg *PoolConfig
+
+	mu    sync.RWMutex
+	nodes []*poolNode
+}
+
+type poolNode struct {
+	client *Client
+	status NodeStatus
+}
+
+// NewPool returns a new Pool over the configured nodes.
+// Every node is assumed healthy until a call or health check fails.
+func NewPool(cfg *PoolConfig) (*Pool, error) {
+	if len(cfg.Nodes) == 0 {
+		return nil, errNoNodes
+	}
+
+	p := &Pool{cfg: cfg}
+	for _, nodeCfg := range cfg.Nodes {
+		client, err := NewClient(nodeCfg)
+		if err != nil {
+			return nil, err
+		}
+
+		p.nodes = append(p.nodes, &poolNode{
+			client: client,
+			status: NodeStatus{URL: client.url, Healthy: true},
+		})
+	}
+
+	return p, nil
+}
+
+// Run health-checks every node until the context is done.
+func (p *Pool) Run(ctx context.Context) {
+	interval := p.cfg.HealthCheckInterval
+	if interval <= 0 {
+		interval = 10 * time.Second
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		p.CheckHealth(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// CheckHealth queries latestblockheight on every node and records the result.
+func (p *Pool) CheckHealth(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, node := range p.nodes {
+		wg.Add(1)
+		go func(node *poolNode) {
+			defer wg.Done()
+
+			height, err := node.client.LatestBlockHeight(ctx)
+
+			p.mu.Lock()
+			defer p.mu.Unlock()
+
+			node.status.Healthy = err == nil
+			node.status.Err = err
+			if err == nil {
+				node.status.Height = height
+			}
+		}(node)
+	}
+	wg.Wait()
+}
+
+// Status returns the last known state of every node.
+func (p *Pool) Status() []NodeStatus {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	res := make([]NodeStatus, 0, len(p.nodes))
+	for _, node := range p.nodes {
+		res = append(res, node.status)
+	}
+	return res
+}
+
+// candidates returns the nodes in the order reads should try them:
+// healthy nodes by descending height, then unhealthy nodes as a last resort.
+func (p *Pool) candidates() []*poolNode {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	res := make([]*poolNode, len(p.nodes))
+	copy(res, p.nodes)
+
+	sort.SliceStable(res, func(i, j int) bool {
+		a, b := res[i].status, res[j].status
+		if a.Healthy != b.Healthy {
+			return a.Healthy
+		}
+		return a.Height > b.Height
+	})
+	return res
+}
+
+func (p *Pool) markFailed(node *poolNode, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	node.status.Healthy = false
+	node.status.Err = err
+}
+
+func (p *Pool) markHealthy(node *poolNode) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	node.status.Healthy = true
+	node.status.Err = nil
+}
+
+// failover reports whether another node may answer differently.
+func failover(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	return errors.Is(err, ErrTransport) || errors.Is(err, ErrInternal) || errors.Is(err, ErrDecode)
+}
+
+// read runs fn against each candidate node until one succeeds or returns an error that is not node specific.
+func (p *Pool) read(ctx context.Context, fn func(c *Client) error) error {
+	var err error
+	for _, node := range p.candidates() {
+		if err = fn(node.client); err == nil {
+			p.markHealthy(node)
+			return nil
+		}
+
+		if !failover(err) {
+			return err
+		}
+		p.markFailed(node, err)
+
+		if ctx.Err() != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// Call sends any method to the most up-to-date healthy node and returns the raw result.
+// Only methods known to be reads fail over to other nodes.
+func (p *Pool) Call(ctx context.Context, method string, params ...interface{}) (json.RawMessage, error) {
+	if !idempotent(method) {
+		return p.candidates()[0].client.Call(ctx, method, params...)
+	}
+
+	var res json.RawMessage
+	err := p.read(ctx, func(c *Client) (err error) {
+		res, err = c.Call(ctx, method, params...)
+		return err
+	})
+	return res, err
+}
+
+// Batch returns an empty Batch bound to the most up-to-date healthy node.
+func (p *Pool) Batch() *Batch {
+	return p.candidates()[0].client.Batch()
+}
+
+// SendTransaction broadcasts the transaction to every node.
+// It succeeds if at least one node accepts the transaction.
+func (p *Pool) SendTransaction(ctx context.Context, txHex string) (ids.TransactionID, error) {
+	type result struct {
+		id  ids.TransactionID
+		err error
+	}
+
+	results := make(chan result, len(p.nodes))
+	for _, node := range p.nodes {
+		go func(node *poolNode) {
+			id, err := node.client.SendTransaction(ctx, txHex)
+			if err != nil && failover(err) {
+				p.markFailed(node, err)
+			}
+			results <- result{id: id, err: err}
+		}(node)
+	}
+
+	var id ids.TransactionID
+	var errs []error
+	for range p.nodes {
+		res := <-results
+		if res.err != nil {
+			errs = append(errs, res.err)
+			continue
+		}
+		if id == "" {
+			id = res.id
+		}
+	}
+
+	if id == "" {
+		return "", fmt.Errorf("broadcast failed on %d nodes : %w", len(errs), errs[0])
+	}
+	return id, nil
+}
+
+// GetBlockHeight the block height for the given the block hash.
+func (p *Pool) GetBlockHeight(ctx context.Context, blockHash ids.BlockHash) (int64, error) {
+	var res int64
+	err := p.read(ctx, func(c *Client) (err error) {
+		res, err = c.GetBlockHeight(ctx, blockHash)
+		return err
+	})
+	return res, err
+}
+
+// GetBestBlockHash returns the block hash of the head of the best valid chain.
+func (p *Pool) GetBestBlockHash(ctx context.Context) (ids.BlockHash, error) {
+	var res ids.BlockHash
+	err := p.read(ctx, func(c *Client) (err error) {
+		res, err = c.GetBestBlockHash(ctx)
+		return err
+	})
+	return res, err
+}
+
+// GetBlock returns information about a block from a block height.
+func (p *Pool) GetBlock(ctx context.Context, blockNumber int64) (*Block, error) {
+	var res *Block
+	err := p.read(ctx, func(c *Client) (err error) {
+		res, err = c.GetBlock(ctx, blockNumber)
+		return err
+	})
+	return res, err
+}
+
+// GetBlockHash returns the block hash of a block at the given block height in the best valid chain.
+func (p *Pool) GetBlockHash(ctx context.Context, height int64) (ids.BlockHash, error) {
+	var res ids.BlockHash
+	err := p.read(ctx, func(c *Client) (err error) {
+		res, err = c.GetBlockHash(ctx, height)
+		return err
+	})
+	return res, err
+}
+
+// GetTransaction returns a transaction with metadata given the transaction ID.
+func (p *Pool) GetTransaction(ctx context.Context, txID ids.TransactionID) (*GetTransactionResponse, error) {
+	var res *GetTransactionResponse
+	err := p.read(ctx, func(c *Client) (err error) {
+		res, err = c.GetTransaction(ctx, txID)
+		return err
+	})
+	return res, err
+}
+
+// GetTransition returns a transition given an id.
+func (p *Pool) GetTransition(ctx context.Context, transitionID ids.TransitionID) (*Transition, error) {
+	var res *Transition
+	err := p.read(ctx, func(c *Client) (err error) {
+		res, err = c.GetTransition(ctx, transitionID)
+		return err
+	})
+	return res, err
+}
+
+// LatestLedgerRoot returns the latest ledger root.
+func (p *Pool) LatestLedgerRoot(ctx context.Context) (ids.LedgerRoot, error) {
+	var res ids.LedgerRoot
+	err := p.read(ctx, func(c *Client) (err error) {
+		res, err = c.LatestLedgerRoot(ctx)
+		return err
+	})
+	return res, err
+}
+
+// GetLedgerProof returns the ledger proof of a given record commitment.
+func (p *Pool) GetLedgerProof(ctx context.Context, recordCommitment ids.Commitment) (string, error) {
+	var res string
+	err := p.read(ctx, func(c *Client) (err error) {
+		res, err = c.GetLedgerProof(ctx, recordCommitment)
+		return err
+	})
+	return res, err
+}
+
+// GetCiphertext returns the ciphertext using a given id.
+func (p *Pool) GetCiphertext(ctx context.Context, id ids.CiphertextID) (string, error) {
+	var res string
+	err := p.read(ctx, func(c *Client) (err error) {
+		res, err = c.GetCiphertext(ctx, id)
+		return err
+	})
+	return res, err
+}
+
+// GetBlockHashes returns blockhashes for a given range of block heights.
+func (p *Pool) GetBlockHashes(ctx context.Context, start, end int64) ([]ids.BlockHash, error) {
+	var res []ids.BlockHash
+	err := p.read(ctx, func(c *Client) (err error) {
+		res, err = c.GetBlockHashes(ctx, start, end)
+		return err
+	})
+	return res, err
+}
+
+// GetBlockHeader returns the blockheader of a given block height.
+func (p *Pool) GetBlockHeader(ctx context.Context, height int64) (*BlockHeader, error) {
+	var res *BlockHeader
+	err := p.read(ctx, func(c *Client) (err error) {
+		res, err = c.GetBlockHeader(ctx, height)
+		return err
+	})
+	return res, err
+}
+
+// GetBlocks returns a range of blocks with the given block heights.
+func (p *Pool) GetBlocks(ctx context.Context, start, end int64) ([]Block, error) {
+	var res []Block
+	err := p.read(ctx, func(c *Client) (err error) {
+		res, err = c.GetBlocks(ctx, start, end)
+		return err
+	})
+	return res, err
+}
+
+// GetBlockTransactions returns transactions in a block at a given height.
+func (p *Pool) GetBlockTransactions(ctx context.Context, height int64) (*Transactions, error) {
+	var res *Transactions
+	err := p.read(ctx, func(c *Client) (err error) {
+		res, err = c.GetBlockTransactions(ctx, height)
+		return err
+	})
+	return res, err
+}
+
+// LatestBlock returns the latest block.
+func (p *Pool) LatestBlock(ctx context.Context) (*Block, error) {
+	var res *Block
+	err := p.read(ctx, func(c *Client) (err error) {
+		res, err = c.LatestBlock(ctx)
+		return err
+	})
+	return res, err
+}
+
+// LatestBlockHash returns the latest blockhash.
+func (p *Pool) LatestBlockHash(ctx context.Context) (ids.BlockHash, error) {
+	var res ids.BlockHash
+	err := p.read(ctx, func(c *Client) (err error) {
+		res, err = c.LatestBlockHash(ctx)
+		return err
+	})
+	return res, err
+}
+
+// LatestBlockHeader returns the latest block header.
+func (p *Pool) LatestBlockHeader(ctx context.Context) (*BlockHeader, error) {
+	var res *BlockHeader
+	err := p.read(ctx, func(c *Client) (err error) {
+		res, err = c.LatestBlockHeader(ctx)
+		return err
+	})
+	return res, err
+}
+
+// LatestBlockHeight returns the latest blockheight.
+func (p *Pool) LatestBlockHeight(ctx context.Context) (int64, error) {
+	var res int64
+	err := p.read(ctx, func(c *Client) (err error) {
+		res, err = c.LatestBlockHeight(ctx)
+		return err
+	})
+	return res, err
+}
+
+// LatestBlockTransactions returns a list of transactions for the latest block.
+func (p *Pool) LatestBlockTransactions(ctx context.Context) (*Transactions, error) {
+	var res *Transactions
+	err := p.read(ctx, func(c *Client) (err error) {
+		res, err = c.LatestBlockTransactions(ctx)
+		return err
+	})
+	return res, err
+}
+
+// GetConnectedPeers returns a list of connected peers.
+func (p *Pool) GetConnectedPeers(ctx context.Context) ([]string, error) {
+	var res []string
+	err := p.read(ctx, func(c *Client) (err error) {
+		res, err = c.GetConnectedPeers(ctx)
+		return err
+	})
+	return res, err
+}
+
+// LatestCumulativeWeight returns the cumulative proof-of-work weight of the best chain.
+func (p *Pool) LatestCumulativeWeight(ctx context.Context) (*big.Int, error) {
+	var res *big.Int
+	err := p.read(ctx, func(c *Client) (err error) {
+		res, err = c.LatestCumulativeWeight(ctx)
+		return err
+	})
+	return res, err
+}
+
+// GetBlockTemplate returns the template for the next block to mine.
+func (p *Pool) GetBlockTemplate(ctx context.Context) (*BlockTemplate, error) {
+	var res *BlockTemplate
+	err := p.read(ctx, func(c *Client) (err error) {
+		res, err = c.GetBlockTemplate(ctx)
+		return err
+	})
+	return res, err
+}
+
+// GetMemoryPool returns the unconfirmed transactions in the memory pool of one node.
+func (p *Pool) GetMemoryPool(ctx context.Context) ([]Transaction, error) {
+	var res []Transaction
+	err := p.read(ctx, func(c *Client) (err error) {
+		res, err = c.GetMemoryPool(ctx)
+		return err
+	})
+	return res, err
+}
+
+// GetNodeState returns the state of one node.
+func (p *Pool) GetNodeState(ctx context.Context) (*NodeState, error) {
+	var res *NodeState
+	err := p.read(ctx, func(c *Client) (err error) {
+		res, err = c.GetNodeState(ctx)
+		return err
+	})
+	return res, err
+}
+
+// GetBlockByHash returns the block with the given hash.
+func (p *Pool) GetBlockByHash(ctx context.Context, blockHash ids.BlockHash) (*Block, error) {
+	var res *Block
+	err := p.read(ctx, func(c *Client) (err error) {
+		res, err = c.GetBlockByHash(ctx, blockHash)
+		return err
+	})
+	return res, err
+}
+
+// StreamBlocks streams a range of blocks from the most up-to-date healthy node.
+// On failover the next node resumes after the last block passed to fn, so no block is seen twice.
+func (p *Pool) StreamBlocks(ctx context.Context, start, end int64, fn func(Block) error) error {
+	if start > end {
+		return errors.New("start > end")
+	}
+
+	next := start
+	return p.read(ctx, func(c *Client) error {
+		return c.StreamBlocks(ctx, next, end, func(block Block) error {
+			if err := fn(block); err != nil {
+				return err
+			}
+			next++
+			return nil
+		})
+	})
+}
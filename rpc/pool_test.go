@@ -0,0 +1,178 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/pinestreetlabs/aleo-wallet-sdk/ids"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strconv"
+	"sync/atomic"
+	"testing"
+)
+
+// testNodeHash returns the block hash reported by a test node at the given height.
+func testNodeHash(height int64) ids.BlockHash {
+	var b [ids.Size]byte
+	b[0] = byte(height)
+	hash, _ := ids.NewBlockHash(b[:])
+	return hash
+}
+
+// newTestNode returns a node config that reports the given height and counts sendtransaction calls.
+func newTestNode(t *testing.T, height int64, sends *int32) *Config {
+	return newTestConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+
+		var req Request
+		json.Unmarshal(body, &req)
+
+		switch req.Method {
+		case latestBlockHeightMethod:
+			json.NewEncoder(w).Encode(Result{ID: req.ID, Result: json.RawMessage(strconv.FormatInt(height, 10))})
+		case sendTransactionMethod:
+			atomic.AddInt32(sends, 1)
+			w.Write([]byte(`{"jsonrpc":"2.0","result":"` + testTxID + `","id":"1"}`))
+		default:
+			w.Write([]byte(`{"jsonrpc":"2.0","result":"` + testNodeHash(height) + `","id":"1"}`))
+		}
+	})
+}
+
+func TestPoolRoutesToHighestNode(t *testing.T) {
+	var sends int32
+	pool, err := NewPool(&PoolConfig{Nodes: []*Config{
+		newTestNode(t, 10, &sends),
+		newTestNode(t, 12, &sends),
+		newTestNode(t, 11, &sends),
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pool.CheckHealth(context.Background())
+
+	hash, err := pool.LatestBlockHash(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if hash != testNodeHash(12) {
+		t.Fatalf("got %s want %s", hash, testNodeHash(12))
+	}
+}
+
+func TestPoolFailover(t *testing.T) {
+	var sends int32
+	down := newTestConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	})
+
+	pool, err := NewPool(&PoolConfig{Nodes: []*Config{down, newTestNode(t, 5, &sends)}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hash, err := pool.GetBlockHash(context.Background(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if hash != testNodeHash(5) {
+		t.Fatalf("got %s want %s", hash, testNodeHash(5))
+	}
+
+	if status := pool.Status(); status[0].Healthy || !status[1].Healthy {
+		t.Fatalf("unexpected status %+v", status)
+	}
+}
+
+func TestPoolBroadcast(t *testing.T) {
+	var sends int32
+	down := newTestConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	})
+
+	pool, err := NewPool(&PoolConfig{Nodes: []*Config{
+		newTestNode(t, 1, &sends),
+		down,
+		newTestNode(t, 1, &sends),
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	id, err := pool.SendTransaction(context.Background(), "deadbeef")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if id != testTxID || sends != 2 {
+		t.Fatalf("got %s from %d nodes want %s from 2", id, sends, testTxID)
+	}
+}
+
+func TestPoolStreamBlocksFailover(t *testing.T) {
+	down := newTestConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	})
+	up := newTestConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"jsonrpc":"2.0","result":[{"block_hash":"` + testNodeHash(1) + `"}],"id":"1"}`))
+	})
+
+	pool, err := NewPool(&PoolConfig{Nodes: []*Config{down, up}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var blocks []Block
+	err = pool.StreamBlocks(context.Background(), 1, 1, func(block Block) error {
+		blocks = append(blocks, block)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(blocks) != 1 || blocks[0].BlockHash != testNodeHash(1) {
+		t.Fatalf("got %+v", blocks)
+	}
+}
+
+// TestPoolMethods checks that Pool offers every exported method of Client with the same signature.
+func TestPoolMethods(t *testing.T) {
+	client := reflect.TypeOf(&Client{})
+	pool := reflect.TypeOf(&Pool{})
+
+	for i := 0; i < client.NumMethod(); i++ {
+		want := client.Method(i)
+
+		got, ok := pool.MethodByName(want.Name)
+		if !ok {
+			t.Errorf("Pool is missing %s", want.Name)
+			continue
+		}
+
+		// Drop the receiver before comparing.
+		if !reflect.DeepEqual(methodParams(got.Type), methodParams(want.Type)) || !reflect.DeepEqual(methodResults(got.Type), methodResults(want.Type)) {
+			t.Errorf("Pool.%s is %s want %s", want.Name, got.Type, want.Type)
+		}
+	}
+}
+
+func methodParams(fn reflect.Type) []reflect.Type {
+	var res []reflect.Type
+	for i := 1; i < fn.NumIn(); i++ {
+		res = append(res, fn.In(i))
+	}
+	return res
+}
+
+func methodResults(fn reflect.Type) []reflect.Type {
+	var res []reflect.Type
+	for i := 0; i < fn.NumOut(); i++ {
+		res = append(res, fn.Out(i))
+	}
+	return res
+}
@@ -0,0 +1,492 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/pinestreetlabs/aleo-wallet-sdk/ids"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// maxRESTBlocks is the largest range served by a single /blocks request.
+const maxRESTBlocks = 50
+
+var errNotJSONTransaction = errors.New("REST nodes only accept JSON transactions")
+
+// RESTClient is a ChainClient for snarkOS nodes serving the REST API, such as
+// GET /testnet3/latest/height, which replaced JSON-RPC in newer releases.
+//
+// REST nodes return testnet3 blocks and transactions. They are mapped onto the
+// testnet2 types of this package: record inputs become serial numbers, record
+// outputs become commitments and ciphertexts, and fields with no REST
+// counterpart, such as the difficulty target, are left empty. Identifiers are
+// validated as the ids types they map to; a response holding one that does not
+// decode, such as a testnet3 identifier with another prefix, fails with ErrDecode.
+//
+// The client shares the connection options of Config and retries reads according
+// to Config.Retry. Interceptors are not applied since REST calls have no JSON-RPC method.
+type RESTClient struct {
+	client *Client
+	base   string
+}
+
+// NewRESTClient returns a new REST client.
+func NewRESTClient(cfg *Config) (*RESTClient, error) {
+	client, err := NewClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	network := cfg.Network
+	if network == "" {
+		network = "testnet3"
+	}
+
+	return &RESTClient{
+		client: client,
+		base:   strings.TrimSuffix(client.url, "/") + "/" + network,
+	}, nil
+}
+
+// LatestBlockHeight returns the height of the latest block.
+func (c *RESTClient) LatestBlockHeight(ctx context.Context) (int64, error) {
+	var res int64
+	if err := c.get(ctx, "/latest/height", &res); err != nil {
+		return 0, err
+	}
+
+	return res, nil
+}
+
+// LatestBlockHash returns the hash of the latest block.
+func (c *RESTClient) LatestBlockHash(ctx context.Context) (ids.BlockHash, error) {
+	var res ids.BlockHash
+	if err := c.get(ctx, "/latest/hash", &res); err != nil {
+		return "", err
+	}
+
+	return res, nil
+}
+
+// LatestBlock returns the latest block.
+func (c *RESTClient) LatestBlock(ctx context.Context) (*Block, error) {
+	return c.block(ctx, "/latest/block")
+}
+
+// LatestBlockHeader returns the header of the latest block.
+func (c *RESTClient) LatestBlockHeader(ctx context.Context) (*BlockHeader, error) {
+	block, err := c.LatestBlock(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &block.BlockHeader, nil
+}
+
+// LatestBlockTransactions returns the transactions of the latest block.
+func (c *RESTClient) LatestBlockTransactions(ctx context.Context) (*Transactions, error) {
+	block, err := c.LatestBlock(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &block.Transactions, nil
+}
+
+// GetBlock returns the block at the given height.
+func (c *RESTClient) GetBlock(ctx context.Context, blockNumber int64) (*Block, error) {
+	return c.block(ctx, "/block/"+strconv.FormatInt(blockNumber, 10))
+}
+
+// GetBlockByHash returns the block with the given hash.
+func (c *RESTClient) GetBlockByHash(ctx context.Context, blockHash ids.BlockHash) (*Block, error) {
+	return c.block(ctx, "/block/"+url.PathEscape(string(blockHash)))
+}
+
+// GetBlocks returns the blocks from start to end, inclusive.
+func (c *RESTClient) GetBlocks(ctx context.Context, start, end int64) ([]Block, error) {
+	if start > end {
+		return nil, errors.New("start > end")
+	}
+
+	var res []Block
+	for s := start; s <= end; s += maxRESTBlocks {
+		e := s + maxRESTBlocks
+		if e > end+1 {
+			e = end + 1
+		}
+
+		var blocks []restBlock
+		if err := c.get(ctx, fmt.Sprintf("/blocks?start=%d&end=%d", s, e), &blocks); err != nil {
+			return nil, err
+		}
+
+		for i := range blocks {
+			block, err := blocks[i].block()
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, *block)
+		}
+	}
+
+	return res, nil
+}
+
+// GetBlockHash returns the hash of the block at the given height.
+func (c *RESTClient) GetBlockHash(ctx context.Context, height int64) (ids.BlockHash, error) {
+	block, err := c.GetBlock(ctx, height)
+	if err != nil {
+		return "", err
+	}
+
+	return block.BlockHash, nil
+}
+
+// GetBlockHeight returns the height of the block with the given hash.
+func (c *RESTClient) GetBlockHeight(ctx context.Context, blockHash ids.BlockHash) (int64, error) {
+	var res int64
+	if err := c.get(ctx, "/height/"+url.PathEscape(string(blockHash)), &res); err != nil {
+		return 0, err
+	}
+
+	return res, nil
+}
+
+// GetBlockHeader returns the header of the block at the given height.
+func (c *RESTClient) GetBlockHeader(ctx context.Context, height int64) (*BlockHeader, error) {
+	block, err := c.GetBlock(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+
+	return &block.BlockHeader, nil
+}
+
+// GetBlockTransactions returns the transactions of the block at the given height.
+func (c *RESTClient) GetBlockTransactions(ctx context.Context, height int64) (*Transactions, error) {
+	var txs []restConfirmedTransaction
+	if err := c.get(ctx, "/block/"+strconv.FormatInt(height, 10)+"/transactions", &txs); err != nil {
+		return nil, err
+	}
+
+	res := &Transactions{}
+	for i := range txs {
+		tx, err := txs[i].transaction()
+		if err != nil {
+			return nil, err
+		}
+		res.Transactions = append(res.Transactions, tx)
+	}
+
+	return res, nil
+}
+
+// GetTransaction returns a transaction with the metadata of the block it is in.
+// The metadata is empty if the transaction is not in a block yet.
+func (c *RESTClient) GetTransaction(ctx context.Context, txID ids.TransactionID) (*GetTransactionResponse, error) {
+	var tx restTransaction
+	if err := c.get(ctx, "/transaction/"+url.PathEscape(string(txID)), &tx); err != nil {
+		return nil, err
+	}
+
+	transaction, err := tx.transaction()
+	if err != nil {
+		return nil, err
+	}
+	res := &GetTransactionResponse{Transaction: transaction}
+
+	var blockHash ids.BlockHash
+	if err := c.get(ctx, "/find/blockHash/"+url.PathEscape(string(txID)), &blockHash); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return res, nil
+		}
+		return nil, err
+	}
+
+	block, err := c.GetBlockByHash(ctx, blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	res.Metadata = TransactionMetadata{
+		BlockHash:      block.BlockHash,
+		BlockHeight:    block.BlockHeader.Metadata.Height,
+		BlockTimestamp: block.BlockHeader.Metadata.Timestamp,
+	}
+	for i, blockTx := range block.Transactions.Transactions {
+		if blockTx.TxID == txID {
+			res.Metadata.TransactionIndex = int64(i)
+			break
+		}
+	}
+
+	return res, nil
+}
+
+// GetTransition returns the transition with the given ID.
+func (c *RESTClient) GetTransition(ctx context.Context, transitionID ids.TransitionID) (*Transition, error) {
+	var txID ids.TransactionID
+	if err := c.get(ctx, "/find/transactionID/"+url.PathEscape(string(transitionID)), &txID); err != nil {
+		return nil, err
+	}
+
+	var tx restTransaction
+	if err := c.get(ctx, "/transaction/"+url.PathEscape(string(txID)), &tx); err != nil {
+		return nil, err
+	}
+
+	transaction, err := tx.transaction()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, transition := range transaction.Transitions {
+		if transition.ID == transitionID {
+			return &transition, nil
+		}
+	}
+
+	return nil, fmt.Errorf("GetTransition : %w : transition %s in transaction %s", ErrNotFound, transitionID, txID)
+}
+
+// SendTransaction broadcasts a JSON transaction and returns its ID.
+func (c *RESTClient) SendTransaction(ctx context.Context, tx string) (ids.TransactionID, error) {
+	if !json.Valid([]byte(tx)) {
+		return "", errNotJSONTransaction
+	}
+
+	var res ids.TransactionID
+	if err := c.do(ctx, "POST", "/transaction/broadcast", []byte(tx), &res); err != nil {
+		return "", err
+	}
+
+	return res, nil
+}
+
+// GetConnectedPeers returns the addresses of the connected peers.
+func (c *RESTClient) GetConnectedPeers(ctx context.Context) ([]string, error) {
+	var res []string
+	if err := c.get(ctx, "/peers/all", &res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetMemoryPool returns the unconfirmed transactions in the node's memory pool.
+func (c *RESTClient) GetMemoryPool(ctx context.Context) ([]Transaction, error) {
+	var txs []restTransaction
+	if err := c.get(ctx, "/memoryPool/transactions", &txs); err != nil {
+		return nil, err
+	}
+
+	var res []Transaction
+	for i := range txs {
+		tx, err := txs[i].transaction()
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, tx)
+	}
+
+	return res, nil
+}
+
+func (c *RESTClient) block(ctx context.Context, path string) (*Block, error) {
+	var res restBlock
+	if err := c.get(ctx, path, &res); err != nil {
+		return nil, err
+	}
+
+	return res.block()
+}
+
+// get fetches path and decodes the response into v, retrying according to the client's policy.
+func (c *RESTClient) get(ctx context.Context, path string, v interface{}) error {
+	policy := c.client.cfg.Retry
+	if policy == nil {
+		return c.do(ctx, "GET", path, nil, v)
+	}
+
+	return policy.do(ctx, func() (bool, error) {
+		err := c.do(ctx, "GET", path, nil, v)
+		return policy.retryable(err), err
+	})
+}
+
+func (c *RESTClient) do(ctx context.Context, method, path string, body []byte, v interface{}) error {
+	r, err := c.client.open(ctx, method, c.base+path, body)
+	if err != nil {
+		var httpErr *HTTPError
+		if errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusNotFound {
+			return &restNotFoundError{httpErr}
+		}
+		return err
+	}
+	defer r.Close()
+
+	buf, err := ioutil.ReadAll(r)
+	if err != nil {
+		return &TransportError{Err: err}
+	}
+
+	return decodeJSON(buf, v)
+}
+
+// restNotFoundError is returned when a REST node answers 404.
+type restNotFoundError struct {
+	err *HTTPError
+}
+
+// Error implements the error interface.
+func (e *restNotFoundError) Error() string {
+	return e.err.Error()
+}
+
+// Is reports whether the target is ErrNotFound.
+func (e *restNotFoundError) Is(target error) bool {
+	return target == ErrNotFound
+}
+
+type restBlock struct {
+	BlockHash    ids.BlockHash              `json:"block_hash"`
+	PreviousHash ids.BlockHash              `json:"previous_hash"`
+	Header       restBlockHeader            `json:"header"`
+	Transactions []restConfirmedTransaction `json:"transactions"`
+}
+
+type restBlockHeader struct {
+	PreviousStateRoot ids.LedgerRoot `json:"previous_state_root"`
+	TransactionsRoot  string         `json:"transactions_root"`
+	Metadata          struct {
+		Height    int64 `json:"height"`
+		Timestamp int64 `json:"timestamp"`
+	} `json:"metadata"`
+}
+
+func (b *restBlock) block() (*Block, error) {
+	block := &Block{
+		BlockHash:         b.BlockHash,
+		PreviousBlockHash: b.PreviousHash,
+		BlockHeader: BlockHeader{
+			PrevLedgerRoot:   b.Header.PreviousStateRoot,
+			TransactionsRoot: b.Header.TransactionsRoot,
+			Metadata: BlockHeaderMetadata{
+				Height:    b.Header.Metadata.Height,
+				Timestamp: b.Header.Metadata.Timestamp,
+			},
+		},
+	}
+
+	for i := range b.Transactions {
+		tx, err := b.Transactions[i].transaction()
+		if err != nil {
+			return nil, err
+		}
+		block.Transactions.Transactions = append(block.Transactions.Transactions, tx)
+	}
+
+	return block, nil
+}
+
+// restConfirmedTransaction is a transaction in a block. Newer nodes wrap it with
+// its status under "transaction", older ones list the transaction itself.
+type restConfirmedTransaction struct {
+	restTransaction
+	Transaction *restTransaction `json:"transaction"`
+}
+
+func (t *restConfirmedTransaction) transaction() (Transaction, error) {
+	if t.Transaction != nil {
+		return t.Transaction.transaction()
+	}
+	return t.restTransaction.transaction()
+}
+
+type restTransaction struct {
+	ID        ids.TransactionID `json:"id"`
+	Execution *struct {
+		GlobalStateRoot ids.LedgerRoot   `json:"global_state_root"`
+		Transitions     []restTransition `json:"transitions"`
+	} `json:"execution"`
+	Fee *struct {
+		GlobalStateRoot ids.LedgerRoot `json:"global_state_root"`
+		Transition      restTransition `json:"transition"`
+	} `json:"fee"`
+}
+
+func (t *restTransaction) transaction() (Transaction, error) {
+	tx := Transaction{TxID: t.ID}
+
+	var transitions []*restTransition
+	if t.Execution != nil {
+		tx.LedgerRoot = t.Execution.GlobalStateRoot
+		for i := range t.Execution.Transitions {
+			transitions = append(transitions, &t.Execution.Transitions[i])
+		}
+	}
+
+	if t.Fee != nil {
+		if tx.LedgerRoot == "" {
+			tx.LedgerRoot = t.Fee.GlobalStateRoot
+		}
+		transitions = append(transitions, &t.Fee.Transition)
+	}
+
+	for _, rt := range transitions {
+		transition, err := rt.transition()
+		if err != nil {
+			return Transaction{}, err
+		}
+		tx.Transitions = append(tx.Transitions, transition)
+	}
+
+	return tx, nil
+}
+
+type restTransition struct {
+	ID      ids.TransitionID `json:"id"`
+	Proof   string           `json:"proof"`
+	Inputs  []restIO         `json:"inputs"`
+	Outputs []restIO         `json:"outputs"`
+}
+
+// restIO is a transition input or output. Its ID is only an ids type for records.
+type restIO struct {
+	Type  string `json:"type"`
+	ID    string `json:"id"`
+	Value string `json:"value"`
+}
+
+func (t *restTransition) transition() (Transition, error) {
+	transition := Transition{ID: t.ID, Proof: t.Proof}
+
+	for _, input := range t.Inputs {
+		if input.Type == "record" {
+			sn, err := ids.ParseSerialNumber(input.ID)
+			if err != nil {
+				return Transition{}, &DecodeError{Err: err}
+			}
+			transition.SerialNumbers = append(transition.SerialNumbers, sn)
+		}
+	}
+
+	for _, output := range t.Outputs {
+		if output.Type == "record" {
+			cm, err := ids.ParseCommitment(output.ID)
+			if err != nil {
+				return Transition{}, &DecodeError{Err: err}
+			}
+			transition.Commitments = append(transition.Commitments, cm)
+			transition.Ciphertexts = append(transition.Ciphertexts, output.Value)
+		}
+	}
+
+	return transition, nil
+}
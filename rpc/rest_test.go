@@ -0,0 +1,254 @@
+package rpc
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/pinestreetlabs/aleo-wallet-sdk/ids"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+// restTestBlock is formatted with the height, block hash, previous block hash and transaction.
+const restTestBlock = `{
+	"block_hash": "%[2]s",
+	"previous_hash": "%[3]s",
+	"header": {
+		"previous_state_root": "%[5]s",
+		"transactions_root": "tr1root",
+		"metadata": {"network": 3, "height": %[1]d, "timestamp": 1700000000}
+	},
+	"transactions": [{
+		"status": "accepted",
+		"type": "execute",
+		"index": 0,
+		"transaction": %[4]s
+	}]
+}`
+
+// restTestTransactionFormat is formatted with the transaction, ledger root, transition, serial number,
+// commitment and fee transition identifiers.
+const restTestTransactionFormat = `{
+	"type": "execute",
+	"id": "%s",
+	"execution": {
+		"global_state_root": "%[2]s",
+		"transitions": [{
+			"id": "%[3]s",
+			"inputs": [{"type": "record", "id": "%[4]s"}, {"type": "public", "id": "in1", "value": "5u64"}],
+			"outputs": [{"type": "record", "id": "%[5]s", "value": "record1ciphertext"}],
+			"proof": "proof1"
+		}]
+	},
+	"fee": {
+		"global_state_root": "%[2]s",
+		"transition": {"id": "%[6]s"}
+	}
+}`
+
+var (
+	restTxID, _         = ids.NewTransactionID(restTestBytes("tx"))
+	restRoot, _         = ids.NewLedgerRoot(restTestBytes("root"))
+	restTransitionID, _ = ids.NewTransitionID(restTestBytes("transition"))
+	restSerial, _       = ids.NewSerialNumber(restTestBytes("serial"))
+	restCommitment, _   = ids.NewCommitment(restTestBytes("commitment"))
+	restFeeID, _        = ids.NewTransitionID(restTestBytes("fee"))
+
+	restTestTransaction = fmt.Sprintf(restTestTransactionFormat, restTxID, restRoot, restTransitionID, restSerial, restCommitment, restFeeID)
+	// restTestnet3Transaction holds testnet3 identifiers, which do not decode as the ids types.
+	restTestnet3Transaction = fmt.Sprintf(restTestTransactionFormat, restTxID, restRoot, "au1transition", restSerial, restCommitment, restFeeID)
+)
+
+func restTestBytes(seed string) []byte {
+	sum := sha256.Sum256([]byte(seed))
+	return sum[:]
+}
+
+// restTestBlockJSON returns the block at height holding tx.
+func restTestBlockJSON(height int64, tx string) string {
+	return fmt.Sprintf(restTestBlock, height, testNodeHash(height), testNodeHash(height-1), tx, restRoot)
+}
+
+func newTestRESTClient(t *testing.T) (*RESTClient, *[]string) {
+	t.Helper()
+
+	var paths []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.Method+" "+r.URL.RequestURI())
+
+		switch r.URL.Path {
+		case "/testnet3/latest/height":
+			fmt.Fprint(w, `7`)
+		case "/testnet3/latest/hash":
+			fmt.Fprintf(w, `"%s"`, testNodeHash(7))
+		case "/testnet3/latest/block", "/testnet3/block/7", "/testnet3/block/" + string(testNodeHash(7)):
+			fmt.Fprint(w, restTestBlockJSON(7, restTestTransaction))
+		case "/testnet3/block/8":
+			fmt.Fprint(w, restTestBlockJSON(8, restTestnet3Transaction))
+		case "/testnet3/blocks":
+			var start, end int
+			fmt.Sscan(r.URL.Query().Get("start"), &start)
+			fmt.Sscan(r.URL.Query().Get("end"), &end)
+			fmt.Fprint(w, "[")
+			for h := start; h < end; h++ {
+				if h > start {
+					fmt.Fprint(w, ",")
+				}
+				fmt.Fprint(w, restTestBlockJSON(int64(h), restTestTransaction))
+			}
+			fmt.Fprint(w, "]")
+		case "/testnet3/height/" + string(testNodeHash(7)):
+			fmt.Fprint(w, `7`)
+		case "/testnet3/block/7/transactions":
+			fmt.Fprintf(w, `[{"status":"accepted","type":"execute","index":0,"transaction":%s}]`, restTestTransaction)
+		case "/testnet3/transaction/" + string(restTxID):
+			fmt.Fprint(w, restTestTransaction)
+		case "/testnet3/find/blockHash/" + string(restTxID):
+			fmt.Fprintf(w, `"%s"`, testNodeHash(7))
+		case "/testnet3/find/transactionID/" + string(restTransitionID):
+			fmt.Fprintf(w, `"%s"`, restTxID)
+		case "/testnet3/transaction/broadcast":
+			body, _ := ioutil.ReadAll(r.Body)
+			if r.Method != "POST" || string(body) != restTestTransaction {
+				http.Error(w, "bad broadcast", http.StatusBadRequest)
+				return
+			}
+			fmt.Fprintf(w, `"%s"`, restTxID)
+		case "/testnet3/peers/all":
+			fmt.Fprint(w, `["127.0.0.1:4133"]`)
+		case "/testnet3/memoryPool/transactions":
+			fmt.Fprintf(w, `[%s]`, restTestTransaction)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	client, err := NewRESTClient(&Config{URL: srv.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return client, &paths
+}
+
+func TestRESTClientBlocks(t *testing.T) {
+	client, paths := newTestRESTClient(t)
+	ctx := context.Background()
+
+	height, err := client.LatestBlockHeight(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if height != 7 {
+		t.Fatalf("got %d want %d", height, 7)
+	}
+
+	block, err := client.GetBlockByHash(ctx, testNodeHash(7))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if block.PreviousBlockHash != testNodeHash(6) || block.BlockHeader.Metadata.Height != 7 || block.BlockHeader.PrevLedgerRoot != restRoot {
+		t.Fatalf("unexpected block %+v", block)
+	}
+
+	transitions := block.Transactions.Transactions[0].Transitions
+	if len(transitions) != 2 || transitions[0].SerialNumbers[0] != restSerial || transitions[0].Commitments[0] != restCommitment ||
+		transitions[0].Ciphertexts[0] != "record1ciphertext" || transitions[1].ID != restFeeID {
+		t.Fatalf("unexpected transitions %+v", transitions)
+	}
+
+	*paths = nil
+	blocks, err := client.GetBlocks(ctx, 0, 119)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(blocks) != 120 || blocks[119].BlockHash != testNodeHash(119) {
+		t.Fatalf("got %d blocks", len(blocks))
+	}
+
+	want := []string{"GET /testnet3/blocks?start=0&end=50", "GET /testnet3/blocks?start=50&end=100", "GET /testnet3/blocks?start=100&end=120"}
+	if fmt.Sprint(*paths) != fmt.Sprint(want) {
+		t.Fatalf("got %v want %v", *paths, want)
+	}
+}
+
+func TestRESTClientTransactions(t *testing.T) {
+	client, _ := newTestRESTClient(t)
+	ctx := context.Background()
+
+	tx, err := client.GetTransaction(ctx, restTxID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tx.Transaction.TxID != restTxID || tx.Metadata.BlockHash != testNodeHash(7) || tx.Metadata.BlockHeight != 7 {
+		t.Fatalf("unexpected transaction %+v", tx)
+	}
+
+	transition, err := client.GetTransition(ctx, restTransitionID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if transition.Proof != "proof1" {
+		t.Fatalf("got %s want %s", transition.Proof, "proof1")
+	}
+
+	id, err := client.SendTransaction(ctx, restTestTransaction)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id != restTxID {
+		t.Fatalf("got %s want %s", id, restTxID)
+	}
+
+	if _, err := client.SendTransaction(ctx, "deadbeef"); err != errNotJSONTransaction {
+		t.Fatalf("got %v want %v", err, errNotJSONTransaction)
+	}
+
+	mempool, err := client.GetMemoryPool(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(mempool) != 1 || mempool[0].TxID != restTxID {
+		t.Fatalf("unexpected memory pool %+v", mempool)
+	}
+
+	if _, err := client.GetTransaction(ctx, "at1missing"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("got %v want %v", err, ErrNotFound)
+	}
+}
+
+func TestRESTClientIDs(t *testing.T) {
+	client, _ := newTestRESTClient(t)
+	ctx := context.Background()
+
+	block, err := client.GetBlock(ctx, 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buf, err := json.Marshal(block)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got Block
+	if err := json.Unmarshal(buf, &got); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(&got, block) {
+		t.Fatalf("got %+v want %+v", got, block)
+	}
+
+	if _, err := client.GetBlock(ctx, 8); !errors.Is(err, ErrDecode) {
+		t.Fatalf("got %v want %v", err, ErrDecode)
+	}
+}
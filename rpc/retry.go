@@ -0,0 +1,154 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"github.com/pinestreetlabs/aleo-wallet-sdk/ids"
+	"math"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// RetryPolicy configures how the client retries failed calls.
+//
+// Read methods are retried whenever the error is retryable. sendtransaction is
+// only retried when TransactionID is set and gettransaction confirms that the
+// transaction did not land.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first. Values below 2 disable retries.
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry. Defaults to 100ms.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between attempts. Zero means no cap.
+	MaxBackoff time.Duration
+	// Multiplier scales the delay after each attempt. Defaults to 2.
+	Multiplier float64
+	// Jitter is the fraction of each delay, between 0 and 1, that is randomized.
+	Jitter float64
+	// RetryOn lists the error classes that are retried. Defaults to ErrTransport and ErrInternal.
+	RetryOn []error
+	// TransactionID derives the transaction ID from a serialized transaction.
+	// Without it, sendtransaction is never retried.
+	TransactionID func(txHex string) (ids.TransactionID, error)
+}
+
+// DefaultRetryPolicy returns a policy suitable for long-running jobs.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:    5,
+		InitialBackoff: 200 * time.Millisecond,
+		MaxBackoff:     10 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+	}
+}
+
+// retryable reports whether err belongs to one of the retried error classes.
+func (p *RetryPolicy) retryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	// Client errors other than rate limiting will fail again.
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) && httpErr.StatusCode < http.StatusInternalServerError && httpErr.StatusCode != http.StatusTooManyRequests {
+		return false
+	}
+
+	classes := p.RetryOn
+	if len(classes) == 0 {
+		classes = []error{ErrTransport, ErrInternal}
+	}
+
+	for _, class := range classes {
+		if errors.Is(err, class) {
+			return true
+		}
+	}
+	return false
+}
+
+// backoff returns the delay before the given retry, starting at 0.
+func (p *RetryPolicy) backoff(retry int) time.Duration {
+	initial := p.InitialBackoff
+	if initial <= 0 {
+		initial = 100 * time.Millisecond
+	}
+
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 2
+	}
+
+	d := float64(initial) * math.Pow(multiplier, float64(retry))
+	if max := float64(p.MaxBackoff); max > 0 && d > max {
+		d = max
+	}
+
+	if jitter := math.Min(p.Jitter, 1); jitter > 0 {
+		d -= d * jitter * rand.Float64()
+	}
+
+	return time.Duration(d)
+}
+
+// do runs fn until it succeeds, reports that it may not be retried, or runs out of attempts.
+func (p *RetryPolicy) do(ctx context.Context, fn func() (bool, error)) error {
+	for attempt := 1; ; attempt++ {
+		retry, err := fn()
+		if err == nil || !retry || attempt >= p.MaxAttempts {
+			return err
+		}
+
+		timer := time.NewTimer(p.backoff(attempt - 1))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+// idempotent reports whether a method can be repeated without side effects.
+// Methods the client does not know, such as those sent through Call, are assumed not to be.
+func idempotent(method string) bool {
+	return readMethods[method]
+}
+
+// sendTransaction broadcasts txHex, retrying only once the node confirms the transaction did not land.
+func (c *Client) sendTransaction(ctx context.Context, txHex string, v *ids.TransactionID) error {
+	send := func() error {
+		return c.callOnce(ctx, sendTransactionMethod, v, txHex)
+	}
+
+	policy := c.cfg.Retry
+	if policy == nil || policy.TransactionID == nil {
+		return send()
+	}
+
+	txID, err := policy.TransactionID(txHex)
+	if err != nil {
+		return send()
+	}
+
+	return policy.do(ctx, func() (bool, error) {
+		err := send()
+		if !policy.retryable(err) {
+			return false, err
+		}
+
+		var res GetTransactionResponse
+		switch lookupErr := c.callOnce(ctx, getTransactionMethod, &res, txID); {
+		case lookupErr == nil:
+			// The broadcast reached the node before the failure.
+			*v = txID
+			return false, nil
+		case errors.Is(lookupErr, ErrNotFound):
+			return true, err
+		default:
+			return false, err
+		}
+	})
+}
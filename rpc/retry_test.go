@@ -0,0 +1,150 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/pinestreetlabs/aleo-wallet-sdk/ids"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func testRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond,
+	}
+}
+
+func TestRetryReads(t *testing.T) {
+	var attempts int
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"jsonrpc":"2.0","result":9,"id":"1"}`))
+	})
+	client.cfg.Retry = testRetryPolicy()
+
+	height, err := client.LatestBlockHeight(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if height != 9 || attempts != 3 {
+		t.Fatalf("got height %d after %d attempts want 9 after 3", height, attempts)
+	}
+}
+
+func TestRetryNotRetryable(t *testing.T) {
+	var attempts int
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.Write([]byte(`{"jsonrpc":"2.0","error":{"code":-32602,"message":"Invalid params"},"id":"1"}`))
+	})
+	client.cfg.Retry = testRetryPolicy()
+
+	if _, err := client.GetBlock(context.Background(), -1); !errors.Is(err, ErrInvalidParams) {
+		t.Fatalf("got %v want %v", err, ErrInvalidParams)
+	}
+
+	if attempts != 1 {
+		t.Fatalf("got %d attempts want 1", attempts)
+	}
+}
+
+func TestRetryCall(t *testing.T) {
+	var attempts int
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	})
+	client.cfg.Retry = testRetryPolicy()
+
+	if _, err := client.Call(context.Background(), "createtransaction"); !errors.Is(err, ErrTransport) {
+		t.Fatalf("got %v want %v", err, ErrTransport)
+	}
+
+	if attempts != 1 {
+		t.Fatalf("got %d attempts want 1", attempts)
+	}
+
+	if _, err := client.Call(context.Background(), latestBlockHeightMethod); !errors.Is(err, ErrTransport) {
+		t.Fatalf("got %v want %v", err, ErrTransport)
+	}
+
+	if attempts != 4 {
+		t.Fatalf("got %d attempts want 4", attempts)
+	}
+}
+
+func TestRetrySendTransaction(t *testing.T) {
+	tests := []struct {
+		name      string
+		landed    bool
+		wantSends int
+	}{
+		{name: "not landed", landed: false, wantSends: 2},
+		{name: "landed", landed: true, wantSends: 1},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var sends int
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				body, _ := ioutil.ReadAll(r.Body)
+
+				var req Request
+				json.Unmarshal(body, &req)
+
+				switch req.Method {
+				case sendTransactionMethod:
+					sends++
+					if sends == 1 {
+						http.Error(w, "timeout", http.StatusGatewayTimeout)
+						return
+					}
+					w.Write([]byte(`{"jsonrpc":"2.0","result":"` + testTxID + `","id":"1"}`))
+				case getTransactionMethod:
+					if tc.landed {
+						w.Write([]byte(`{"jsonrpc":"2.0","result":{"transaction":{"transaction_id":"` + testTxID + `"}},"id":"1"}`))
+						return
+					}
+					w.Write([]byte(`{"jsonrpc":"2.0","error":{"code":-32603,"message":"transaction ` + testTxID + ` not found"},"id":"1"}`))
+				}
+			})
+			client.cfg.Retry = testRetryPolicy()
+			client.cfg.Retry.TransactionID = func(string) (ids.TransactionID, error) { return testTxID, nil }
+
+			id, err := client.SendTransaction(context.Background(), "deadbeef")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if id != testTxID || sends != tc.wantSends {
+				t.Fatalf("got %s after %d sends want %s after %d", id, sends, testTxID, tc.wantSends)
+			}
+		})
+	}
+}
+
+func TestSendTransactionNoRetryWithoutID(t *testing.T) {
+	var sends int
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		sends++
+		http.Error(w, "timeout", http.StatusGatewayTimeout)
+	})
+	client.cfg.Retry = testRetryPolicy()
+
+	if _, err := client.SendTransaction(context.Background(), "deadbeef"); !errors.Is(err, ErrTransport) {
+		t.Fatalf("got %v want %v", err, ErrTransport)
+	}
+
+	if sends != 1 {
+		t.Fatalf("got %d sends want 1", sends)
+	}
+}
@@ -3,11 +3,20 @@ package rpc
 
 import (
 	"bytes"
+	"compress/gzip"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"github.com/pinestreetlabs/aleo-wallet-sdk/ids"
+	"io"
 	"io/ioutil"
+	"math/big"
 	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
 )
 
 // Config holds the configuration for the RPC client.
@@ -16,183 +25,338 @@ type Config struct {
 	Password string
 	Host     string
 	Port     string
+
+	// URL is the full endpoint of the node, e.g. https://node.example.com:3032/rpc.
+	// When set it takes precedence over Host and Port, and its user info is used
+	// for basic auth if User is empty.
+	URL string
+
+	// CAFile is a PEM bundle of certificate authorities trusted for https endpoints,
+	// in place of the system roots.
+	CAFile string
+	// CertFile and KeyFile hold a PEM client certificate and key for mutual TLS.
+	CertFile string
+	KeyFile  string
+	// Proxy is the URL of an http, https or socks5 proxy. When empty the proxy is
+	// taken from the environment.
+	Proxy string
+	// Headers are set on every request, replacing the client's own headers of the same name.
+	Headers http.Header
+	// Transport replaces the client's HTTP transport. When set, CAFile, CertFile,
+	// KeyFile, Proxy and the connection options below are ignored.
+	Transport http.RoundTripper
+
+	// MaxResponseSize is the largest decompressed response body accepted, in bytes. Defaults to 64 MiB.
+	MaxResponseSize int64
+	// DisableCompression stops asking the node for gzip responses.
+	DisableCompression bool
+	// MaxIdleConns and MaxIdleConnsPerHost bound the idle connections kept for reuse.
+	// They default to 100 and 16.
+	MaxIdleConns        int
+	MaxIdleConnsPerHost int
+	// MaxConnsPerHost bounds the connections to the node, including active ones. Zero means unlimited.
+	MaxConnsPerHost int
+	// IdleConnTimeout is how long an idle connection is kept. Defaults to 90s.
+	IdleConnTimeout time.Duration
+	// KeepAlive is the TCP keep-alive period. Defaults to 30s, negative disables keep-alive probes.
+	KeepAlive time.Duration
+
+	// Network is the network name in REST paths, such as /testnet3/latest/height.
+	// It is only used by RESTClient and defaults to testnet3.
+	Network string
+
+	// Retry configures retries of failed calls. A nil policy disables retries.
+	Retry *RetryPolicy
+
+	// Interceptors wrap every call made by the client, outermost first.
+	Interceptors []Interceptor
 }
 
 // Client maintains a connection to the Aleo client.
 type Client struct {
+	// id is the last request ID issued by the client.
+	// It is accessed atomically and must stay 64-bit aligned.
+	id uint64
+
 	cfg        *Config
 	httpClient *http.Client
+	invoker    Invoker
+
+	url      string
+	user     string
+	password string
 }
 
 // NewClient returns a new RPC client.
 func NewClient(cfg *Config) (*Client, error) {
-	httpClient := &http.Client{}
+	endpoint, err := cfg.endpoint()
+	if err != nil {
+		return nil, fmt.Errorf("NewClient : %w", err)
+	}
 
-	return &Client{
+	transport, err := cfg.transport()
+	if err != nil {
+		return nil, fmt.Errorf("NewClient : %w", err)
+	}
+
+	httpClient := &http.Client{Transport: transport}
+
+	c := &Client{
 		httpClient: httpClient,
 		cfg:        cfg,
-	}, nil
+		user:       cfg.User,
+		password:   cfg.Password,
+	}
+
+	if c.user == "" && endpoint.User != nil {
+		c.user = endpoint.User.Username()
+		c.password, _ = endpoint.User.Password()
+	}
+	endpoint.User = nil
+	c.url = endpoint.String()
+	c.invoker = chainInterceptors(cfg.Interceptors, c.send)
+
+	return c, nil
 }
 
-// newRequest creates a serialized request.
-func newRequest(client *Client, body []byte) (*Result, error) {
-	url := fmt.Sprintf("http://%s:%s", client.cfg.Host, client.cfg.Port)
+// nextID returns a unique request ID.
+func (c *Client) nextID() string {
+	return strconv.FormatUint(atomic.AddUint64(&c.id, 1), 10)
+}
 
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(body))
+// post sends a serialized request and returns the raw response body.
+func post(ctx context.Context, client *Client, body []byte) ([]byte, error) {
+	r, err := client.open(ctx, "POST", client.url, body)
 	if err != nil {
 		return nil, err
 	}
+	defer r.Close()
 
-	req.Header.Add("content-type", "application/json")
+	buf, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, &TransportError{Err: err}
+	}
 
-	req.SetBasicAuth(client.cfg.User, client.cfg.Password)
+	return buf, nil
+}
 
-	resp, err := client.httpClient.Do(req)
+// open sends an HTTP request and returns the response body once its status is checked.
+// The body is decompressed and limited to MaxResponseSize. The caller must close it.
+func (c *Client) open(ctx context.Context, method, url string, body []byte) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
 	if err != nil {
 		return nil, err
 	}
 
-	buf, err := ioutil.ReadAll(resp.Body)
+	if body != nil {
+		req.Header.Add("content-type", "application/json")
+	}
+
+	if !c.cfg.DisableCompression {
+		req.Header.Add("accept-encoding", "gzip")
+	}
+
+	req.SetBasicAuth(c.user, c.password)
+
+	for key, values := range c.cfg.Headers {
+		req.Header[http.CanonicalHeaderKey(key)] = values
+	}
+
+	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, err
+		return nil, &TransportError{Err: err}
 	}
 
-	var result Result
-	if err := json.Unmarshal(buf, &result); err != nil {
-		return nil, err
+	r := &responseBody{Reader: resp.Body, body: resp.Body}
+
+	if strings.EqualFold(resp.Header.Get("content-encoding"), "gzip") {
+		gz, err := gzip.NewReader(resp.Body)
+		if err != nil {
+			r.Close()
+			return nil, &TransportError{Err: err}
+		}
+		r.Reader = gz
 	}
 
-	if result.Error != nil {
-		return nil, fmt.Errorf("%v", result.Error)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		defer r.Close()
+
+		buf, _ := ioutil.ReadAll(io.LimitReader(r.Reader, maxErrorBody))
+		return nil, &HTTPError{StatusCode: resp.StatusCode, Status: resp.Status, Body: buf}
 	}
 
-	return &result, nil
+	r.Reader = &limitReader{r: r.Reader, n: c.maxResponseSize()}
+
+	return r, nil
 }
 
-func newRequestBody(rpcVersion int, id string, method string, params []json.RawMessage) ([]byte, error) {
-	req := &Request{
-		Method:     method,
-		Params:     params,
-		ID:         id,
-		RPCVersion: "2.0",
+func (c *Client) maxResponseSize() int64 {
+	if c.cfg.MaxResponseSize > 0 {
+		return c.cfg.MaxResponseSize
 	}
-	return json.Marshal(req)
+	return defaultMaxResponseSize
 }
 
-// GetBlockHeight the block height for the given the block hash.
-func (c *Client) GetBlockHeight(blockHash string) (int64, error) {
-	param, err := json.Marshal(blockHash)
+// newRequest creates a serialized request.
+func newRequest(ctx context.Context, client *Client, body []byte) (*Result, error) {
+	buf, err := post(ctx, client, body)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 
-	req, err := newRequestBody(2, "", getBlockHeightMethod, []json.RawMessage{param})
-	if err != nil {
-		return 0, err
+	var result Result
+	if err := decodeJSON(buf, &result); err != nil {
+		return nil, err
 	}
 
-	resp, err := newRequest(c, req)
-	if err != nil {
-		return 0, err
+	if result.Error != nil {
+		return nil, result.Error
 	}
 
-	var count int64
-	if err := json.Unmarshal(resp.Result, &count); err != nil {
-		return 0, err
+	return &result, nil
+}
+
+// call sends a request for method and decodes its result into v, retrying according to the client's policy.
+func (c *Client) call(ctx context.Context, method string, v interface{}, params ...interface{}) error {
+	return c.retry(ctx, method, func() error {
+		return c.callOnce(ctx, method, v, params...)
+	})
+}
+
+// retry runs fn according to the client's policy for method.
+func (c *Client) retry(ctx context.Context, method string, fn func() error) error {
+	policy := c.cfg.Retry
+	if policy == nil || !idempotent(method) {
+		return fn()
 	}
 
-	return count, nil
+	return policy.do(ctx, func() (bool, error) {
+		err := fn()
+		return policy.retryable(err), err
+	})
 }
 
-// GetBestBlockHash returns the block hash of the head of the best valid chain.
-func (c *Client) GetBestBlockHash() (string, error) {
-	req, err := newRequestBody(2, "", getBestBlockHashMethod, nil)
+// callOnce sends a single request for method and decodes its result into v.
+func (c *Client) callOnce(ctx context.Context, method string, v interface{}, params ...interface{}) error {
+	rawParams, err := marshalParams(params...)
 	if err != nil {
-		return "", err
+		return err
 	}
 
-	resp, err := newRequest(c, req)
+	result, err := c.invoker(ctx, method, rawParams)
 	if err != nil {
-		return "", err
+		return err
 	}
 
-	var res string
-	if err := json.Unmarshal(resp.Result, &res); err != nil {
-		return "", err
+	if v == nil {
+		return nil
 	}
 
-	return res, nil
+	return decodeJSON(result, v)
 }
 
-// GetBlock returns information about a block from a block height.
-func (c *Client) GetBlock(blockNumber int64) (*Block, error) {
-	param, err := json.Marshal(blockNumber)
-	if err != nil {
-		return nil, err
+// send is the innermost Invoker. It sends a single request, or a batch for BatchMethod.
+func (c *Client) send(ctx context.Context, method string, params []json.RawMessage) (json.RawMessage, error) {
+	if method == BatchMethod {
+		body, err := json.Marshal(params)
+		if err != nil {
+			return nil, err
+		}
+		return post(ctx, c, body)
 	}
 
-	req, err := newRequestBody(2, "", getBlockMethod, []json.RawMessage{param})
+	req, err := newRequestBody(2, c.nextID(), method, params)
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := newRequest(c, req)
+	resp, err := newRequest(ctx, c, req)
 	if err != nil {
 		return nil, err
 	}
 
-	var res Block
-	if err := json.Unmarshal(resp.Result, &res); err != nil {
-		return nil, err
-	}
+	return resp.Result, nil
+}
 
-	return &res, nil
+// marshalParams serializes each positional param.
+func marshalParams(params ...interface{}) ([]json.RawMessage, error) {
+	var rawParams []json.RawMessage
+	for _, p := range params {
+		param, err := json.Marshal(p)
+		if err != nil {
+			return nil, err
+		}
+		rawParams = append(rawParams, param)
+	}
+	return rawParams, nil
 }
 
-// GetBlockHash returns the block hash of a block at the given block height in the best valid chain.
-func (c *Client) GetBlockHash(height int64) (string, error) {
-	param, err := json.Marshal(height)
-	if err != nil {
-		return "", err
+func newRequestBody(rpcVersion int, id string, method string, params []json.RawMessage) ([]byte, error) {
+	req := &Request{
+		Method:     method,
+		Params:     params,
+		ID:         id,
+		RPCVersion: "2.0",
 	}
+	return json.Marshal(req)
+}
 
-	req, err := newRequestBody(2, "", getBlockHashMethod, []json.RawMessage{param})
-	if err != nil {
-		return "", err
+// GetBlockHeight the block height for the given the block hash.
+func (c *Client) GetBlockHeight(ctx context.Context, blockHash ids.BlockHash) (int64, error) {
+	var res int64
+	if err := c.call(ctx, getBlockHeightMethod, &res, blockHash); err != nil {
+		return 0, err
 	}
 
-	resp, err := newRequest(c, req)
-	if err != nil {
-		return "", err
+	return res, nil
+}
+
+// Call sends any method with the given params and returns the raw result.
+// Params are marshalled to JSON, so a json.RawMessage is sent as is.
+// Only methods the client knows to be reads are retried.
+func (c *Client) Call(ctx context.Context, method string, params ...interface{}) (json.RawMessage, error) {
+	var res json.RawMessage
+	if err := c.call(ctx, method, &res, params...); err != nil {
+		return nil, err
 	}
 
-	var res string
-	if err := json.Unmarshal(resp.Result, &res); err != nil {
+	return res, nil
+}
+
+// GetBestBlockHash returns the block hash of the head of the best valid chain.
+func (c *Client) GetBestBlockHash(ctx context.Context) (ids.BlockHash, error) {
+	var res ids.BlockHash
+	if err := c.call(ctx, getBestBlockHashMethod, &res); err != nil {
 		return "", err
 	}
 
 	return res, nil
 }
 
-// GetTransaction returns a transaction with metadata given the transaction ID.
-func (c *Client) GetTransaction(txID string) (*GetTransactionResponse, error) {
-	param, err := json.Marshal(txID)
-	if err != nil {
+// GetBlock returns information about a block from a block height.
+func (c *Client) GetBlock(ctx context.Context, blockNumber int64) (*Block, error) {
+	var res Block
+	if err := c.call(ctx, getBlockMethod, &res, blockNumber); err != nil {
 		return nil, err
 	}
 
-	req, err := newRequestBody(2, "", getTransactionMethod, []json.RawMessage{param})
-	if err != nil {
-		return nil, err
-	}
+	return &res, nil
+}
 
-	resp, err := newRequest(c, req)
-	if err != nil {
-		return nil, err
+// GetBlockHash returns the block hash of a block at the given block height in the best valid chain.
+func (c *Client) GetBlockHash(ctx context.Context, height int64) (ids.BlockHash, error) {
+	var res ids.BlockHash
+	if err := c.call(ctx, getBlockHashMethod, &res, height); err != nil {
+		return "", err
 	}
 
+	return res, nil
+}
+
+// GetTransaction returns a transaction with metadata given the transaction ID.
+func (c *Client) GetTransaction(ctx context.Context, txID ids.TransactionID) (*GetTransactionResponse, error) {
 	var res GetTransactionResponse
-	if err := json.Unmarshal(resp.Result, &res); err != nil {
+	if err := c.call(ctx, getTransactionMethod, &res, txID); err != nil {
 		return nil, err
 	}
 
@@ -200,24 +364,9 @@ func (c *Client) GetTransaction(txID string) (*GetTransactionResponse, error) {
 }
 
 // GetTransition returns a transition given an id.
-func (c *Client) GetTransition(transitionID string) (*Transition, error) {
-	param, err := json.Marshal(transitionID)
-	if err != nil {
-		return nil, err
-	}
-
-	req, err := newRequestBody(2, "", getTransitionMethod, []json.RawMessage{param})
-	if err != nil {
-		return nil, err
-	}
-
-	resp, err := newRequest(c, req)
-	if err != nil {
-		return nil, err
-	}
-
+func (c *Client) GetTransition(ctx context.Context, transitionID ids.TransitionID) (*Transition, error) {
 	var res Transition
-	if err := json.Unmarshal(resp.Result, &res); err != nil {
+	if err := c.call(ctx, getTransitionMethod, &res, transitionID); err != nil {
 		return nil, err
 	}
 
@@ -226,24 +375,10 @@ func (c *Client) GetTransition(transitionID string) (*Transition, error) {
 
 // SendTransaction sends raw transaction bytes to this node to be added into the mempool.
 // If valid, the transaction will be stored and propagated to all peers.
-func (c *Client) SendTransaction(txHex string) (string, error) {
-	param, err := json.Marshal(txHex)
-	if err != nil {
-		return "", err
-	}
-
-	req, err := newRequestBody(2, "", sendTransactionMethod, []json.RawMessage{param})
-	if err != nil {
-		return "", err
-	}
-
-	resp, err := newRequest(c, req)
-	if err != nil {
-		return "", err
-	}
-
-	var res string
-	if err := json.Unmarshal(resp.Result, &res); err != nil {
+// Failed broadcasts are retried only as described by RetryPolicy.
+func (c *Client) SendTransaction(ctx context.Context, txHex string) (ids.TransactionID, error) {
+	var res ids.TransactionID
+	if err := c.sendTransaction(ctx, txHex, &res); err != nil {
 		return "", err
 	}
 
@@ -251,19 +386,9 @@ func (c *Client) SendTransaction(txHex string) (string, error) {
 }
 
 // LatestLedgerRoot returns the latest ledger root.
-func (c *Client) LatestLedgerRoot() (string, error) {
-	req, err := newRequestBody(2, "", latestLedgerRootMethod, nil)
-	if err != nil {
-		return "", err
-	}
-
-	resp, err := newRequest(c, req)
-	if err != nil {
-		return "", err
-	}
-
-	var res string
-	if err := json.Unmarshal(resp.Result, &res); err != nil {
+func (c *Client) LatestLedgerRoot(ctx context.Context) (ids.LedgerRoot, error) {
+	var res ids.LedgerRoot
+	if err := c.call(ctx, latestLedgerRootMethod, &res); err != nil {
 		return "", err
 	}
 
@@ -271,24 +396,9 @@ func (c *Client) LatestLedgerRoot() (string, error) {
 }
 
 // GetLedgerProof returns the ledger proof of a given record commitment.
-func (c *Client) GetLedgerProof(recordCommitment string) (string, error) {
-	param, err := json.Marshal(recordCommitment)
-	if err != nil {
-		return "", err
-	}
-
-	req, err := newRequestBody(2, "", getLedgerProofMethod, []json.RawMessage{param})
-	if err != nil {
-		return "", err
-	}
-
-	resp, err := newRequest(c, req)
-	if err != nil {
-		return "", err
-	}
-
+func (c *Client) GetLedgerProof(ctx context.Context, recordCommitment ids.Commitment) (string, error) {
 	var res string
-	if err := json.Unmarshal(resp.Result, &res); err != nil {
+	if err := c.call(ctx, getLedgerProofMethod, &res, recordCommitment); err != nil {
 		return "", err
 	}
 
@@ -296,24 +406,9 @@ func (c *Client) GetLedgerProof(recordCommitment string) (string, error) {
 }
 
 // GetCiphertext returns the ciphertext using a given id.
-func (c *Client) GetCiphertext(id string) (string, error) {
-	param, err := json.Marshal(id)
-	if err != nil {
-		return "", err
-	}
-
-	req, err := newRequestBody(2, "", getCiphertextMethod, []json.RawMessage{param})
-	if err != nil {
-		return "", err
-	}
-
-	resp, err := newRequest(c, req)
-	if err != nil {
-		return "", err
-	}
-
+func (c *Client) GetCiphertext(ctx context.Context, id ids.CiphertextID) (string, error) {
 	var res string
-	if err := json.Unmarshal(resp.Result, &res); err != nil {
+	if err := c.call(ctx, getCiphertextMethod, &res, id); err != nil {
 		return "", err
 	}
 
@@ -321,33 +416,13 @@ func (c *Client) GetCiphertext(id string) (string, error) {
 }
 
 // GetBlockHashes returns blockhashes for a given range of block heights.
-func (c *Client) GetBlockHashes(start, end int64) ([]string, error) {
+func (c *Client) GetBlockHashes(ctx context.Context, start, end int64) ([]ids.BlockHash, error) {
 	if start > end {
 		return nil, errors.New("start > end")
 	}
 
-	startParam, err := json.Marshal(start)
-	if err != nil {
-		return nil, err
-	}
-
-	endParam, err := json.Marshal(end)
-	if err != nil {
-		return nil, err
-	}
-
-	req, err := newRequestBody(2, "", getBlockHashesMethod, []json.RawMessage{startParam, endParam})
-	if err != nil {
-		return nil, err
-	}
-
-	resp, err := newRequest(c, req)
-	if err != nil {
-		return nil, err
-	}
-
-	var res []string
-	if err := json.Unmarshal(resp.Result, &res); err != nil {
+	var res []ids.BlockHash
+	if err := c.call(ctx, getBlockHashesMethod, &res, start, end); err != nil {
 		return nil, err
 	}
 
@@ -355,24 +430,9 @@ func (c *Client) GetBlockHashes(start, end int64) ([]string, error) {
 }
 
 // GetBlockHeader returns the blockheader of a given block height.
-func (c *Client) GetBlockHeader(height int64) (*BlockHeader, error) {
-	param, err := json.Marshal(height)
-	if err != nil {
-		return nil, err
-	}
-
-	req, err := newRequestBody(2, "", getBlockHeaderMethod, []json.RawMessage{param})
-	if err != nil {
-		return nil, err
-	}
-
-	resp, err := newRequest(c, req)
-	if err != nil {
-		return nil, err
-	}
-
+func (c *Client) GetBlockHeader(ctx context.Context, height int64) (*BlockHeader, error) {
 	var res BlockHeader
-	if err := json.Unmarshal(resp.Result, &res); err != nil {
+	if err := c.call(ctx, getBlockHeaderMethod, &res, height); err != nil {
 		return nil, err
 	}
 
@@ -380,33 +440,20 @@ func (c *Client) GetBlockHeader(height int64) (*BlockHeader, error) {
 }
 
 // GetBlocks returns a range of blocks with the given block heights.
-func (c *Client) GetBlocks(start, end int64) ([]Block, error) {
+func (c *Client) GetBlocks(ctx context.Context, start, end int64) ([]Block, error) {
 	if start > end {
 		return nil, errors.New("start > end")
 	}
 
-	startParam, err := json.Marshal(start)
-	if err != nil {
-		return nil, err
-	}
-
-	endParam, err := json.Marshal(end)
-	if err != nil {
-		return nil, err
-	}
-
-	req, err := newRequestBody(2, "", getBlocksMethod, []json.RawMessage{startParam, endParam})
-	if err != nil {
-		return nil, err
-	}
-
-	resp, err := newRequest(c, req)
-	if err != nil {
-		return nil, err
-	}
-
 	var res []Block
-	if err := json.Unmarshal(resp.Result, &res); err != nil {
+	err := c.retry(ctx, getBlocksMethod, func() error {
+		res = res[:0]
+		return c.StreamBlocks(ctx, start, end, func(block Block) error {
+			res = append(res, block)
+			return nil
+		})
+	})
+	if err != nil {
 		return nil, err
 	}
 
@@ -414,24 +461,9 @@ func (c *Client) GetBlocks(start, end int64) ([]Block, error) {
 }
 
 // GetBlockTransactions returns transactions in a block at a given height.
-func (c *Client) GetBlockTransactions(height int64) (*Transactions, error) {
-	param, err := json.Marshal(height)
-	if err != nil {
-		return nil, err
-	}
-
-	req, err := newRequestBody(2, "", getBlockTransactionsMethod, []json.RawMessage{param})
-	if err != nil {
-		return nil, err
-	}
-
-	resp, err := newRequest(c, req)
-	if err != nil {
-		return nil, err
-	}
-
+func (c *Client) GetBlockTransactions(ctx context.Context, height int64) (*Transactions, error) {
 	var res Transactions
-	if err := json.Unmarshal(resp.Result, &res); err != nil {
+	if err := c.call(ctx, getBlockTransactionsMethod, &res, height); err != nil {
 		return nil, err
 	}
 
@@ -439,19 +471,9 @@ func (c *Client) GetBlockTransactions(height int64) (*Transactions, error) {
 }
 
 // LatestBlock returns the latest block.
-func (c *Client) LatestBlock() (*Block, error) {
-	req, err := newRequestBody(2, "", latestBlockMethod, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	resp, err := newRequest(c, req)
-	if err != nil {
-		return nil, err
-	}
-
+func (c *Client) LatestBlock(ctx context.Context) (*Block, error) {
 	var res Block
-	if err := json.Unmarshal(resp.Result, &res); err != nil {
+	if err := c.call(ctx, latestBlockMethod, &res); err != nil {
 		return nil, err
 	}
 
@@ -459,19 +481,9 @@ func (c *Client) LatestBlock() (*Block, error) {
 }
 
 // LatestBlockHash returns the latest blockhash.
-func (c *Client) LatestBlockHash() (string, error) {
-	req, err := newRequestBody(2, "", latestBlockHashMethod, nil)
-	if err != nil {
-		return "", err
-	}
-
-	resp, err := newRequest(c, req)
-	if err != nil {
-		return "", err
-	}
-
-	var res string
-	if err := json.Unmarshal(resp.Result, &res); err != nil {
+func (c *Client) LatestBlockHash(ctx context.Context) (ids.BlockHash, error) {
+	var res ids.BlockHash
+	if err := c.call(ctx, latestBlockHashMethod, &res); err != nil {
 		return "", err
 	}
 
@@ -479,19 +491,9 @@ func (c *Client) LatestBlockHash() (string, error) {
 }
 
 // LatestBlockHeader returns the latest blcok header.
-func (c *Client) LatestBlockHeader() (*BlockHeader, error) {
-	req, err := newRequestBody(2, "", latestBlockHeaderMethod, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	resp, err := newRequest(c, req)
-	if err != nil {
-		return nil, err
-	}
-
+func (c *Client) LatestBlockHeader(ctx context.Context) (*BlockHeader, error) {
 	var res BlockHeader
-	if err := json.Unmarshal(resp.Result, &res); err != nil {
+	if err := c.call(ctx, latestBlockHeaderMethod, &res); err != nil {
 		return nil, err
 	}
 
@@ -499,61 +501,102 @@ func (c *Client) LatestBlockHeader() (*BlockHeader, error) {
 }
 
 // LatestBlockHeight returns the latest blockheight.
-func (c *Client) LatestBlockHeight() (int64, error) {
-	req, err := newRequestBody(2, "", latestBlockHeightMethod, nil)
-	if err != nil {
+func (c *Client) LatestBlockHeight(ctx context.Context) (int64, error) {
+	var res int64
+	if err := c.call(ctx, latestBlockHeightMethod, &res); err != nil {
 		return 0, err
 	}
 
-	resp, err := newRequest(c, req)
-	if err != nil {
-		return 0, err
+	return res, nil
+}
+
+// LatestBlockTransactions returns a list of transactions for the latest block.
+func (c *Client) LatestBlockTransactions(ctx context.Context) (*Transactions, error) {
+	var res Transactions
+	if err := c.call(ctx, latestBlockTransactionsMethod, &res); err != nil {
+		return nil, err
 	}
 
-	var res int64
-	if err := json.Unmarshal(resp.Result, &res); err != nil {
-		return 0, err
+	return &res, nil
+}
+
+// GetConnectedPeers returns a list of connected peers.
+func (c *Client) GetConnectedPeers(ctx context.Context) ([]string, error) {
+	var res []string
+	if err := c.call(ctx, getConnectedPeersMethod, &res); err != nil {
+		return nil, err
 	}
 
 	return res, nil
 }
 
-// LatestBlockTransactions returns a list of transactions for the latest block.
-func (c *Client) LatestBlockTransactions() (*Transactions, error) {
-	req, err := newRequestBody(2, "", latestBlockTransactionsMethod, nil)
-	if err != nil {
+// LatestCumulativeWeight returns the cumulative proof-of-work weight of the best chain.
+func (c *Client) LatestCumulativeWeight(ctx context.Context) (*big.Int, error) {
+	var res big.Int
+	if err := c.call(ctx, latestCumulativeWeightMethod, &res); err != nil {
 		return nil, err
 	}
 
-	resp, err := newRequest(c, req)
-	if err != nil {
+	return &res, nil
+}
+
+// GetBlockTemplate returns the template for the next block to mine.
+func (c *Client) GetBlockTemplate(ctx context.Context) (*BlockTemplate, error) {
+	var res BlockTemplate
+	if err := c.call(ctx, getBlockTemplateMethod, &res); err != nil {
 		return nil, err
 	}
 
-	var res Transactions
-	if err := json.Unmarshal(resp.Result, &res); err != nil {
+	return &res, nil
+}
+
+// GetMemoryPool returns the unconfirmed transactions in the node's memory pool.
+func (c *Client) GetMemoryPool(ctx context.Context) ([]Transaction, error) {
+	var res []Transaction
+	if err := c.call(ctx, getMemoryPoolMethod, &res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetNodeState returns the state of the node, including its peers and latest block.
+func (c *Client) GetNodeState(ctx context.Context) (*NodeState, error) {
+	var res NodeState
+	if err := c.call(ctx, getNodeStateMethod, &res); err != nil {
 		return nil, err
 	}
 
 	return &res, nil
 }
 
-// GetConnectedPeers returns a list of connected peers.
-func (c *Client) GetConnectedPeers() ([]string, error) {
-	req, err := newRequestBody(2, "", getConnectedPeersMethod, nil)
+// GetBlockByHash returns the block with the given hash.
+// snarkOS has no single method for it, so the height is looked up first and the block
+// is checked against the hash in case of a reorg in between.
+func (c *Client) GetBlockByHash(ctx context.Context, blockHash ids.BlockHash) (*Block, error) {
+	return getBlockByHash(ctx, c, blockHash)
+}
+
+// blockByHashSource is the subset of the client needed to look up a block by hash.
+type blockByHashSource interface {
+	GetBlockHeight(ctx context.Context, blockHash ids.BlockHash) (int64, error)
+	GetBlock(ctx context.Context, blockNumber int64) (*Block, error)
+}
+
+func getBlockByHash(ctx context.Context, src blockByHashSource, blockHash ids.BlockHash) (*Block, error) {
+	height, err := src.GetBlockHeight(ctx, blockHash)
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := newRequest(c, req)
+	block, err := src.GetBlock(ctx, height)
 	if err != nil {
 		return nil, err
 	}
 
-	var res []string
-	if err := json.Unmarshal(resp.Result, &res); err != nil {
-		return nil, err
+	if block.BlockHash != blockHash {
+		return nil, fmt.Errorf("GetBlockByHash : %w : block %s is no longer at height %d", ErrNotFound, blockHash, height)
 	}
 
-	return res, nil
+	return block, nil
 }
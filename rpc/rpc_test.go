@@ -0,0 +1,100 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// Valid identifiers for test handlers, since results are validated when decoded.
+const (
+	testBlockHash = "ab12r46p298u7tu38uvy6u0a8fd598w22tvh2t7vtxfafag98xytvesnsd6l7"
+	testTxID      = "at10sl5xdzy8edzvh4a3feme3w9hnxq8e0088ffae3vkf0m574ty93svpcqvd"
+)
+
+func newTestConfig(t *testing.T, handler http.HandlerFunc) *Config {
+	t.Helper()
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	host, port, err := net.SplitHostPort(srv.Listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &Config{Host: host, Port: port}
+}
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+
+	client, err := NewClient(newTestConfig(t, handler))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return client
+}
+
+func TestLatestBlockHeight(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"jsonrpc":"2.0","result":42,"id":""}`))
+	})
+
+	height, err := client.LatestBlockHeight(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if height != 42 {
+		t.Fatalf("got %d want %d", height, 42)
+	}
+}
+
+func TestCall(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var req Request
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		params, _ := json.Marshal(req.Params)
+		w.Write([]byte(`{"jsonrpc":"2.0","result":{"method":"` + req.Method + `","params":` + string(params) + `},"id":"1"}`))
+	})
+
+	res, err := client.Call(context.Background(), "getfuturething", json.RawMessage(`{"a":[1,2]}`), 7, "ab1hash")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"method":"getfuturething","params":[{"a":[1,2]},7,"ab1hash"]}`
+	if string(res) != want {
+		t.Fatalf("got %s want %s", res, want)
+	}
+}
+
+func TestContextDeadline(t *testing.T) {
+	done := make(chan struct{})
+	defer close(done)
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-done:
+		case <-r.Context().Done():
+		}
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if _, err := client.LatestBlockHeight(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got %v want %v", err, context.DeadlineExceeded)
+	}
+}
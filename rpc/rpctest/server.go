@@ -0,0 +1,593 @@
+// Package rpctest provides an in-process fake snarkOS JSON-RPC server for tests.
+package rpctest
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"github.com/btcsuite/btcutil/bech32"
+	"github.com/pinestreetlabs/aleo-wallet-sdk/ids"
+	"github.com/pinestreetlabs/aleo-wallet-sdk/rpc"
+	"io/ioutil"
+	"math"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+)
+
+// JSON-RPC 2.0 error codes used by the server.
+const (
+	codeInvalidRequest = -32600
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+	codeInternalError  = -32603
+)
+
+// Call is a request received by the server.
+type Call struct {
+	Method string
+	Params []json.RawMessage
+}
+
+// Server is a fake snarkOS node backed by a scriptable in-memory chain.
+// The zero value is not usable; create one with NewServer.
+type Server struct {
+	srv *httptest.Server
+
+	mu           sync.Mutex
+	blocks       []rpc.Block
+	transactions map[ids.TransactionID]*rpc.GetTransactionResponse
+	transitions  map[ids.TransitionID]*rpc.Transition
+	ciphertexts  map[ids.CiphertextID]string
+	ledgerProofs map[ids.Commitment]string
+	ledgerRoot   ids.LedgerRoot
+	peers        []string
+	mempool      []rpc.Transaction
+	sent         []string
+	errs         map[string]*rpc.Error
+	latency      map[string]time.Duration
+	calls        []Call
+	noBatch      bool
+
+	// minted counts every block ever added so replaced blocks get new hashes.
+	minted int
+}
+
+// NewServer starts a server with an empty chain. Call Close when done.
+func NewServer() *Server {
+	s := &Server{
+		transactions: make(map[ids.TransactionID]*rpc.GetTransactionResponse),
+		transitions:  make(map[ids.TransitionID]*rpc.Transition),
+		ciphertexts:  make(map[ids.CiphertextID]string),
+		ledgerProofs: make(map[ids.Commitment]string),
+		errs:         make(map[string]*rpc.Error),
+		latency:      make(map[string]time.Duration),
+		ledgerRoot:   ids.LedgerRoot(NewID("al", "genesis")),
+	}
+	s.srv = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Close shuts down the server.
+func (s *Server) Close() {
+	s.srv.Close()
+}
+
+// URL returns the base URL of the server.
+func (s *Server) URL() string {
+	return s.srv.URL
+}
+
+// Addr returns the host:port of the server.
+func (s *Server) Addr() string {
+	return s.srv.Listener.Addr().String()
+}
+
+// Config returns a client configuration pointing at the server.
+func (s *Server) Config() *rpc.Config {
+	host, port, _ := net.SplitHostPort(s.Addr())
+	return &rpc.Config{Host: host, Port: port}
+}
+
+// NewID returns a deterministic bech32m identifier with the given prefix, such as "ab" or "at".
+func NewID(prefix string, seed string) string {
+	sum := sha256.Sum256([]byte(prefix + seed))
+	data, _ := bech32.ConvertBits(sum[:], 8, 5, true)
+	id, _ := bech32.EncodeM(prefix, data)
+	return id
+}
+
+// AddBlock appends a block at the next height and returns it.
+// Missing hashes are generated and the block is linked to the current tip.
+// Transactions, transitions and ciphertexts in the block become queryable and leave the memory pool.
+func (s *Server) AddBlock(block rpc.Block) rpc.Block {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	height := int64(len(s.blocks))
+	if block.BlockHash == "" {
+		block.BlockHash = ids.BlockHash(NewID("ab", fmt.Sprintf("%d/%d", height, s.minted)))
+	}
+	if block.PreviousBlockHash == "" && height > 0 {
+		block.PreviousBlockHash = s.blocks[height-1].BlockHash
+	}
+	if block.BlockHeader.Metadata.Timestamp == 0 {
+		block.BlockHeader.Metadata.Timestamp = time.Now().Unix()
+	}
+	block.BlockHeader.Metadata.Height = height
+	block.BlockHeader.PrevLedgerRoot = s.ledgerRoot
+
+	for i, tx := range block.Transactions.Transactions {
+		s.transactions[tx.TxID] = &rpc.GetTransactionResponse{
+			Transaction: tx,
+			Metadata: rpc.TransactionMetadata{
+				BlockHash:        block.BlockHash,
+				BlockHeight:      height,
+				BlockTimestamp:   block.BlockHeader.Metadata.Timestamp,
+				TransactionIndex: int64(i),
+			},
+		}
+
+		for j := range tx.Transitions {
+			transition := tx.Transitions[j]
+			s.transitions[transition.ID] = &transition
+
+			for k, id := range transition.CiphertextIDs {
+				if k < len(transition.Ciphertexts) {
+					s.ciphertexts[id] = transition.Ciphertexts[k]
+				}
+			}
+		}
+	}
+
+	s.blocks = append(s.blocks, block)
+	s.minted++
+	s.removeFromMemoryPool(block.Transactions.Transactions)
+	s.ledgerRoot = ids.LedgerRoot(NewID("al", string(block.BlockHash)))
+
+	return block
+}
+
+// AddBlocks appends n empty blocks.
+func (s *Server) AddBlocks(n int) {
+	for i := 0; i < n; i++ {
+		s.AddBlock(rpc.Block{})
+	}
+}
+
+// Rewind removes every block above height, leaving height as the tip.
+// Use it with AddBlock to simulate a reorg.
+func (s *Server) Rewind(height int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if height+1 >= int64(len(s.blocks)) {
+		return
+	}
+
+	for _, block := range s.blocks[height+1:] {
+		for _, tx := range block.Transactions.Transactions {
+			delete(s.transactions, tx.TxID)
+		}
+	}
+	s.blocks = s.blocks[:height+1]
+	s.ledgerRoot = ids.LedgerRoot(NewID("al", string(s.blocks[height].BlockHash)))
+}
+
+// AddCiphertext makes a ciphertext queryable by its ID.
+func (s *Server) AddCiphertext(id ids.CiphertextID, ciphertext string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.ciphertexts[id] = ciphertext
+}
+
+// AddLedgerProof sets the ledger proof returned for a record commitment.
+func (s *Server) AddLedgerProof(commitment ids.Commitment, proof string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.ledgerProofs[commitment] = proof
+}
+
+// AddMemoryPoolTransaction adds an unconfirmed transaction to the memory pool.
+// It is removed once a block containing it is added.
+func (s *Server) AddMemoryPoolTransaction(tx rpc.Transaction) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.mempool = append(s.mempool, tx)
+}
+
+func (s *Server) removeFromMemoryPool(txs []rpc.Transaction) {
+	mined := make(map[ids.TransactionID]bool, len(txs))
+	for _, tx := range txs {
+		mined[tx.TxID] = true
+	}
+
+	mempool := s.mempool[:0]
+	for _, tx := range s.mempool {
+		if !mined[tx.TxID] {
+			mempool = append(mempool, tx)
+		}
+	}
+	s.mempool = mempool
+}
+
+// SetPeers sets the connected peers reported by the server.
+func (s *Server) SetPeers(peers []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.peers = peers
+}
+
+// SetError makes every call to method fail with err. A nil err clears it.
+func (s *Server) SetError(method string, err *rpc.Error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err == nil {
+		delete(s.errs, method)
+		return
+	}
+	s.errs[method] = err
+}
+
+// SetLatency delays every response to method by d.
+func (s *Server) SetLatency(method string, d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.latency[method] = d
+}
+
+// DisableBatch makes the server reject JSON-RPC batch requests.
+func (s *Server) DisableBatch() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.noBatch = true
+}
+
+// Calls returns every call received so far, in order.
+func (s *Server) Calls() []Call {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	res := make([]Call, len(s.calls))
+	copy(res, s.calls)
+	return res
+}
+
+// CallCount returns the number of calls received for method.
+func (s *Server) CallCount(method string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var n int
+	for _, call := range s.calls {
+		if call.Method == method {
+			n++
+		}
+	}
+	return n
+}
+
+// SentTransactions returns the raw transactions received by sendtransaction.
+func (s *Server) SentTransactions() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	res := make([]string, len(s.sent))
+	copy(res, s.sent)
+	return res
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("content-type", "application/json")
+
+	if body = bytes.TrimSpace(body); len(body) > 0 && body[0] == '[' {
+		s.mu.Lock()
+		noBatch := s.noBatch
+		s.mu.Unlock()
+
+		var reqs []rpc.Request
+		if err := json.Unmarshal(body, &reqs); err != nil || noBatch {
+			json.NewEncoder(w).Encode(&rpc.Result{Error: &rpc.Error{Code: codeInvalidRequest, Message: "Invalid request"}})
+			return
+		}
+
+		results := make([]*rpc.Result, 0, len(reqs))
+		for i := range reqs {
+			results = append(results, s.handle(&reqs[i]))
+		}
+		json.NewEncoder(w).Encode(results)
+		return
+	}
+
+	var req rpc.Request
+	if err := json.Unmarshal(body, &req); err != nil {
+		json.NewEncoder(w).Encode(&rpc.Result{Error: &rpc.Error{Code: codeInvalidRequest, Message: "Invalid request"}})
+		return
+	}
+
+	json.NewEncoder(w).Encode(s.handle(&req))
+}
+
+// handle records and answers a single request.
+func (s *Server) handle(req *rpc.Request) *rpc.Result {
+	s.mu.Lock()
+	s.calls = append(s.calls, Call{Method: req.Method, Params: req.Params})
+	delay := s.latency[req.Method]
+	injected := s.errs[req.Method]
+	s.mu.Unlock()
+
+	if delay > 0 {
+		time.Sleep(delay)
+	}
+
+	res := &rpc.Result{ID: req.ID}
+	if injected != nil {
+		res.Error = injected
+		return res
+	}
+
+	v, err := s.dispatch(req.Method, req.Params)
+	if err != nil {
+		res.Error = err
+		return res
+	}
+
+	buf, marshalErr := json.Marshal(v)
+	if marshalErr != nil {
+		res.Error = &rpc.Error{Code: codeInternalError, Message: marshalErr.Error()}
+		return res
+	}
+	res.Result = buf
+	return res
+}
+
+func (s *Server) dispatch(method string, params []json.RawMessage) (interface{}, *rpc.Error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch method {
+	case "getbestblockhash", "latestblockhash":
+		tip, err := s.tip()
+		if err != nil {
+			return nil, err
+		}
+		return tip.BlockHash, nil
+	case "latestblock":
+		return s.tip()
+	case "latestblockheader":
+		// Headers are returned by pointer because big.Int only marshals through one.
+		tip, err := s.tip()
+		if err != nil {
+			return nil, err
+		}
+		return &tip.BlockHeader, nil
+	case "latestblockheight":
+		return int64(len(s.blocks)) - 1, nil
+	case "latestblocktransactions":
+		tip, err := s.tip()
+		if err != nil {
+			return nil, err
+		}
+		return tip.Transactions, nil
+	case "latestledgerroot":
+		return s.ledgerRoot, nil
+	case "latestcumulativeweight":
+		return s.cumulativeWeight(), nil
+	case "getconnectedpeers":
+		return s.connectedPeers(), nil
+	case "getmemorypool":
+		if s.mempool == nil {
+			return []rpc.Transaction{}, nil
+		}
+		return s.mempool, nil
+	case "getnodestate":
+		state := &rpc.NodeState{
+			CandidatePeers:         []string{},
+			ConnectedPeers:         s.connectedPeers(),
+			LatestBlockHeight:      int64(len(s.blocks)) - 1,
+			LatestCumulativeWeight: s.cumulativeWeight(),
+			Launched:               "0 minutes ago",
+			NumberOfConnectedPeers: len(s.peers),
+			Software:               "snarkOS rpctest",
+			Status:                 "Ready",
+			Type:                   "Client",
+			Version:                12,
+		}
+		if tip, err := s.tip(); err == nil {
+			state.LatestBlockHash = tip.BlockHash
+		}
+		return state, nil
+	case "getblocktemplate":
+		tip, err := s.tip()
+		if err != nil {
+			return nil, err
+		}
+		return &rpc.BlockTemplate{
+			PreviousBlockHash: tip.BlockHash,
+			BlockHeight:       int64(len(s.blocks)),
+			Time:              time.Now().Unix(),
+			DifficultyTarget:  tip.BlockHeader.Metadata.DifficultyTarget.Uint64(),
+			CumulativeWeight:  s.cumulativeWeight(),
+			LedgerRoot:        s.ledgerRoot,
+			Transactions:      append([]rpc.Transaction{}, s.mempool...),
+		}, nil
+	case "getblock", "getblockhash", "getblockheader", "getblocktransactions":
+		var height int64
+		if err := parseParams(params, &height); err != nil {
+			return nil, err
+		}
+		block, err := s.block(height)
+		if err != nil {
+			return nil, err
+		}
+		switch method {
+		case "getblockhash":
+			return block.BlockHash, nil
+		case "getblockheader":
+			return &block.BlockHeader, nil
+		case "getblocktransactions":
+			return block.Transactions, nil
+		}
+		return block, nil
+	case "getblockheight":
+		var hash ids.BlockHash
+		if err := parseParams(params, &hash); err != nil {
+			return nil, err
+		}
+		for i := range s.blocks {
+			if s.blocks[i].BlockHash == hash {
+				return int64(i), nil
+			}
+		}
+		return nil, notFound("block %s", hash)
+	case "getblocks", "getblockhashes":
+		var start, end int64
+		if err := parseParams(params, &start, &end); err != nil {
+			return nil, err
+		}
+		if start > end {
+			return nil, &rpc.Error{Code: codeInvalidParams, Message: "Invalid params", Data: quote("start > end")}
+		}
+		var blocks []rpc.Block
+		var hashes []ids.BlockHash
+		for h := start; h <= end; h++ {
+			block, err := s.block(h)
+			if err != nil {
+				return nil, err
+			}
+			blocks = append(blocks, *block)
+			hashes = append(hashes, block.BlockHash)
+		}
+		if method == "getblockhashes" {
+			return hashes, nil
+		}
+		return blocks, nil
+	case "gettransaction":
+		var id ids.TransactionID
+		if err := parseParams(params, &id); err != nil {
+			return nil, err
+		}
+		tx, ok := s.transactions[id]
+		if !ok {
+			return nil, notFound("transaction %s", id)
+		}
+		return tx, nil
+	case "gettransition":
+		var id ids.TransitionID
+		if err := parseParams(params, &id); err != nil {
+			return nil, err
+		}
+		transition, ok := s.transitions[id]
+		if !ok {
+			return nil, notFound("transition %s", id)
+		}
+		return transition, nil
+	case "getciphertext":
+		var id ids.CiphertextID
+		if err := parseParams(params, &id); err != nil {
+			return nil, err
+		}
+		ciphertext, ok := s.ciphertexts[id]
+		if !ok {
+			return nil, notFound("ciphertext %s", id)
+		}
+		return ciphertext, nil
+	case "getledgerproof":
+		var commitment ids.Commitment
+		if err := parseParams(params, &commitment); err != nil {
+			return nil, err
+		}
+		proof, ok := s.ledgerProofs[commitment]
+		if !ok {
+			return nil, notFound("commitment %s", commitment)
+		}
+		return proof, nil
+	case "sendtransaction":
+		var txHex string
+		if err := parseParams(params, &txHex); err != nil {
+			return nil, err
+		}
+		s.sent = append(s.sent, txHex)
+		return NewID("at", txHex), nil
+	}
+
+	return nil, &rpc.Error{Code: codeMethodNotFound, Message: "Method not found"}
+}
+
+func (s *Server) connectedPeers() []string {
+	if s.peers == nil {
+		return []string{}
+	}
+	return s.peers
+}
+
+// cumulativeWeight sums the weight of every block, u64::MAX / difficulty_target as in snarkOS.
+// Blocks without a difficulty target weigh 1.
+func (s *Server) cumulativeWeight() *big.Int {
+	maxTarget := new(big.Int).SetUint64(math.MaxUint64)
+
+	weight := new(big.Int)
+	for i := range s.blocks {
+		target := &s.blocks[i].BlockHeader.Metadata.DifficultyTarget
+		if target.Sign() <= 0 {
+			weight.Add(weight, big.NewInt(1))
+			continue
+		}
+		weight.Add(weight, new(big.Int).Div(maxTarget, target))
+	}
+	return weight
+}
+
+func (s *Server) tip() (*rpc.Block, *rpc.Error) {
+	if len(s.blocks) == 0 {
+		return nil, notFound("latest block")
+	}
+	return &s.blocks[len(s.blocks)-1], nil
+}
+
+func (s *Server) block(height int64) (*rpc.Block, *rpc.Error) {
+	if height < 0 || height >= int64(len(s.blocks)) {
+		return nil, notFound("block %d", height)
+	}
+	return &s.blocks[height], nil
+}
+
+// parseParams decodes positional params into out.
+func parseParams(params []json.RawMessage, out ...interface{}) *rpc.Error {
+	if len(params) != len(out) {
+		return &rpc.Error{Code: codeInvalidParams, Message: "Invalid params", Data: quote(fmt.Sprintf("expected %d params, got %d", len(out), len(params)))}
+	}
+
+	for i := range out {
+		if err := json.Unmarshal(params[i], out[i]); err != nil {
+			return &rpc.Error{Code: codeInvalidParams, Message: "Invalid params", Data: quote(err.Error())}
+		}
+	}
+	return nil
+}
+
+func notFound(format string, args ...interface{}) *rpc.Error {
+	return &rpc.Error{Code: codeInternalError, Message: fmt.Sprintf(format, args...) + " not found"}
+}
+
+func quote(s string) json.RawMessage {
+	buf, _ := json.Marshal(s)
+	return buf
+}
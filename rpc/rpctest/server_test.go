@@ -0,0 +1,216 @@
+package rpctest
+
+import (
+	"context"
+	"errors"
+	"github.com/pinestreetlabs/aleo-wallet-sdk/ids"
+	"github.com/pinestreetlabs/aleo-wallet-sdk/rpc"
+	"testing"
+	"time"
+)
+
+func newClient(t *testing.T, s *Server) *rpc.Client {
+	t.Helper()
+
+	client, err := rpc.NewClient(s.Config())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func TestServerChain(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	tx := rpc.Transaction{
+		TxID: ids.TransactionID(NewID("at", "tx")),
+		Transitions: []rpc.Transition{{
+			ID:            ids.TransitionID(NewID("as", "transition")),
+			CiphertextIDs: []ids.CiphertextID{ids.CiphertextID(NewID("ar", "ciphertext"))},
+			Ciphertexts:   []string{"deadbeef"},
+		}},
+	}
+
+	s.AddBlocks(2)
+	block := s.AddBlock(rpc.Block{Transactions: rpc.Transactions{Transactions: []rpc.Transaction{tx}}})
+
+	client := newClient(t, s)
+	ctx := context.Background()
+
+	height, err := client.LatestBlockHeight(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if height != 2 {
+		t.Fatalf("got %d want %d", height, 2)
+	}
+
+	prev, err := client.GetBlock(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if block.PreviousBlockHash != prev.BlockHash {
+		t.Fatalf("got %s want %s", block.PreviousBlockHash, prev.BlockHash)
+	}
+
+	res, err := client.GetTransaction(ctx, tx.TxID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Metadata.BlockHeight != 2 || res.Metadata.BlockHash != block.BlockHash {
+		t.Fatalf("unexpected metadata %+v", res.Metadata)
+	}
+
+	ciphertext, err := client.GetCiphertext(ctx, ids.CiphertextID(NewID("ar", "ciphertext")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ciphertext != "deadbeef" {
+		t.Fatalf("got %s want %s", ciphertext, "deadbeef")
+	}
+
+	if _, err := client.GetTransaction(ctx, ids.TransactionID(NewID("at", "missing"))); !errors.Is(err, rpc.ErrNotFound) {
+		t.Fatalf("got %v want %v", err, rpc.ErrNotFound)
+	}
+
+	if n := s.CallCount("gettransaction"); n != 2 {
+		t.Fatalf("got %d calls want %d", n, 2)
+	}
+}
+
+func TestServerRewind(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	s.AddBlocks(3)
+	orphan := s.AddBlock(rpc.Block{})
+
+	s.Rewind(2)
+	replacement := s.AddBlock(rpc.Block{})
+
+	if orphan.BlockHash == replacement.BlockHash {
+		t.Fatal("expected a new block hash after rewind")
+	}
+
+	hash, err := newClient(t, s).GetBlockHash(context.Background(), 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hash != replacement.BlockHash {
+		t.Fatalf("got %s want %s", hash, replacement.BlockHash)
+	}
+}
+
+func TestServerInjection(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	client := newClient(t, s)
+
+	s.SetError("latestledgerroot", &rpc.Error{Code: -32602, Message: "Invalid params"})
+	if _, err := client.LatestLedgerRoot(context.Background()); !errors.Is(err, rpc.ErrInvalidParams) {
+		t.Fatalf("got %v want %v", err, rpc.ErrInvalidParams)
+	}
+
+	s.SetError("latestledgerroot", nil)
+	if _, err := client.LatestLedgerRoot(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	s.SetLatency("getconnectedpeers", 100*time.Millisecond)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if _, err := client.GetConnectedPeers(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got %v want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestServerBatch(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	s.AddBlocks(2)
+	client := newClient(t, s)
+
+	for _, disabled := range []bool{false, true} {
+		if disabled {
+			s.DisableBatch()
+		}
+
+		var a, b ids.BlockHash
+		batch := client.Batch()
+		batch.GetBlockHash(0, &a)
+		batch.GetBlockHash(1, &b)
+
+		if err := batch.Send(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+
+		if a == "" || b == "" || a == b {
+			t.Fatalf("unexpected hashes %q %q", a, b)
+		}
+	}
+}
+
+func TestServerNodeState(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	mined := rpc.Transaction{TxID: ids.TransactionID(NewID("at", "mined"))}
+	pending := rpc.Transaction{TxID: ids.TransactionID(NewID("at", "pending"))}
+
+	s.AddBlocks(2)
+	s.AddMemoryPoolTransaction(mined)
+	s.AddMemoryPoolTransaction(pending)
+	tip := s.AddBlock(rpc.Block{Transactions: rpc.Transactions{Transactions: []rpc.Transaction{mined}}})
+	s.SetPeers([]string{"127.0.0.1:4132"})
+
+	client := newClient(t, s)
+	ctx := context.Background()
+
+	mempool, err := client.GetMemoryPool(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(mempool) != 1 || mempool[0].TxID != pending.TxID {
+		t.Fatalf("got %v want %s", mempool, pending.TxID)
+	}
+
+	state, err := client.GetNodeState(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state.LatestBlockHeight != 2 || state.LatestBlockHash != tip.BlockHash || state.NumberOfConnectedPeers != 1 {
+		t.Fatalf("unexpected node state %+v", state)
+	}
+
+	weight, err := client.LatestCumulativeWeight(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if weight.Int64() != 3 || state.LatestCumulativeWeight.Cmp(weight) != 0 {
+		t.Fatalf("got %v and %v want %d", weight, state.LatestCumulativeWeight, 3)
+	}
+
+	template, err := client.GetBlockTemplate(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if template.BlockHeight != 3 || template.PreviousBlockHash != tip.BlockHash || len(template.Transactions) != 1 {
+		t.Fatalf("unexpected block template %+v", template)
+	}
+
+	block, err := client.GetBlockByHash(ctx, tip.BlockHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if block.BlockHeader.Metadata.Height != 2 {
+		t.Fatalf("got %d want %d", block.BlockHeader.Metadata.Height, 2)
+	}
+
+	if _, err := client.GetBlockByHash(ctx, ids.BlockHash(NewID("ab", "missing"))); !errors.Is(err, rpc.ErrNotFound) {
+		t.Fatalf("got %v want %v", err, rpc.ErrNotFound)
+	}
+}
@@ -0,0 +1,171 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// StreamBlocks fetches a range of blocks and passes each one to fn as it is decoded,
+// so the response is never held in memory as a whole. A non-nil error from fn stops the stream
+// and is returned as is. StreamBlocks goes through the client's interceptors but is never retried,
+// since fn may already have seen some blocks.
+func (c *Client) StreamBlocks(ctx context.Context, start, end int64, fn func(Block) error) error {
+	if start > end {
+		return errors.New("start > end")
+	}
+
+	rawParams, err := marshalParams(start, end)
+	if err != nil {
+		return err
+	}
+
+	invoker := chainInterceptors(c.cfg.Interceptors, func(ctx context.Context, method string, params []json.RawMessage) (json.RawMessage, error) {
+		return nil, c.stream(ctx, method, params, func(dec *json.Decoder) error {
+			return decodeArray(dec, func() error {
+				var block Block
+				if err := dec.Decode(&block); err != nil {
+					return err
+				}
+				if err := fn(block); err != nil {
+					return &callbackError{err: err}
+				}
+				return nil
+			})
+		})
+	})
+
+	_, err = invoker(ctx, getBlocksMethod, rawParams)
+	return err
+}
+
+// stream sends a single request and passes the decoder, positioned at the start of the result, to fn.
+// Errors from fn are decode errors unless wrapped in a callbackError.
+func (c *Client) stream(ctx context.Context, method string, params []json.RawMessage, fn func(dec *json.Decoder) error) error {
+	req, err := newRequestBody(2, c.nextID(), method, params)
+	if err != nil {
+		return err
+	}
+
+	r, err := c.open(ctx, "POST", c.url, req)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	err = decodeResultStream(&transportReader{r: r}, fn)
+
+	var cbErr *callbackError
+	var transportErr *TransportError
+	var rpcErr *Error
+	switch {
+	case err == nil:
+		return nil
+	case errors.As(err, &cbErr):
+		return cbErr.err
+	case errors.As(err, &transportErr), errors.As(err, &rpcErr):
+		return err
+	}
+
+	return &DecodeError{Err: err}
+}
+
+// decodeResultStream reads a JSON-RPC response and calls fn with the decoder positioned at its result.
+func decodeResultStream(r io.Reader, fn func(dec *json.Decoder) error) error {
+	dec := json.NewDecoder(r)
+
+	if err := expectDelim(dec, '{'); err != nil {
+		return err
+	}
+
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+
+		switch tok {
+		case "result":
+			if err := fn(dec); err != nil {
+				return err
+			}
+		case "error":
+			var rpcErr *Error
+			if err := dec.Decode(&rpcErr); err != nil {
+				return err
+			}
+			if rpcErr != nil {
+				return rpcErr
+			}
+		default:
+			var skip json.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				return err
+			}
+		}
+	}
+
+	return expectDelim(dec, '}')
+}
+
+// decodeArray calls fn for each element of a JSON array, leaving the decoder positioned at the element.
+// A null array has no elements.
+func decodeArray(dec *json.Decoder, fn func() error) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	if tok == nil {
+		return nil
+	}
+
+	if tok != json.Delim('[') {
+		return fmt.Errorf("expected array, got %v", tok)
+	}
+
+	for dec.More() {
+		if err := fn(); err != nil {
+			return err
+		}
+	}
+
+	return expectDelim(dec, ']')
+}
+
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	if tok != delim {
+		return fmt.Errorf("expected %v, got %v", delim, tok)
+	}
+
+	return nil
+}
+
+// callbackError carries an error returned by the caller's callback through the decoder.
+type callbackError struct {
+	err error
+}
+
+func (e *callbackError) Error() string {
+	return e.err.Error()
+}
+
+// transportReader wraps read errors in a TransportError so they can be told apart from decode errors.
+type transportReader struct {
+	r io.Reader
+}
+
+func (t *transportReader) Read(p []byte) (int, error) {
+	n, err := t.r.Read(p)
+	if err != nil && err != io.EOF {
+		err = &TransportError{Err: err}
+	}
+	return n, err
+}
@@ -0,0 +1,78 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"github.com/pinestreetlabs/aleo-wallet-sdk/ids"
+	"net/http"
+	"testing"
+)
+
+const (
+	blockOne   = "ab1enjr4a2ygmgcqj0ckcwwnr5wgv7fge2rq2kzax006dx8xfnztkjq8d3tyl"
+	blockTwo   = "ab1ry75pnknza6fx88e8slfspjkl06erqefekkjvkpvwehunxrhygaswdrp6n"
+	blockThree = "ab1v8zspjsy0jcsk3zzjcu3dgt0ec22hs5cky0gpzvpx8nl3uqt4qmskchmtk"
+)
+
+func TestStreamBlocks(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":"1","result":[{"block_hash":"` + blockOne + `"},{"block_hash":"` + blockTwo + `"},{"block_hash":"` + blockThree + `"}],"jsonrpc":"2.0"}`))
+	})
+
+	var hashes []ids.BlockHash
+	err := client.StreamBlocks(context.Background(), 1, 3, func(block Block) error {
+		hashes = append(hashes, block.BlockHash)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(hashes) != 3 || hashes[0] != blockOne || hashes[2] != blockThree {
+		t.Fatalf("got %v", hashes)
+	}
+
+	stop := errors.New("stop")
+	var n int
+	err = client.StreamBlocks(context.Background(), 1, 3, func(block Block) error {
+		n++
+		return stop
+	})
+	if err != stop || n != 1 {
+		t.Fatalf("got %v after %d blocks want %v after 1", err, n, stop)
+	}
+
+	blocks, err := client.GetBlocks(context.Background(), 1, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(blocks) != 3 || blocks[1].BlockHash != blockTwo {
+		t.Fatalf("got %v", blocks)
+	}
+}
+
+func TestStreamBlocksErrors(t *testing.T) {
+	tests := map[string]struct {
+		body string
+		want error
+	}{
+		"rpc error":   {`{"jsonrpc":"2.0","error":{"code":-32603,"message":"block 9 not found"},"id":"1"}`, ErrNotFound},
+		"not json":    {`<html>not json</html>`, ErrDecode},
+		"truncated":   {`{"jsonrpc":"2.0","result":[{"block_hash":"ab1one"},`, ErrDecode},
+		"wrong type":  {`{"jsonrpc":"2.0","result":{"block_hash":"ab1one"},"id":"1"}`, ErrDecode},
+		"wrong block": {`{"jsonrpc":"2.0","result":[{"block_hash":1}],"id":"1"}`, ErrDecode},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(tc.body))
+			})
+
+			if _, err := client.GetBlocks(context.Background(), 1, 3); !errors.Is(err, tc.want) {
+				t.Fatalf("got %v want %v", err, tc.want)
+			}
+		})
+	}
+}
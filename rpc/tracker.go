@@ -0,0 +1,343 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/pinestreetlabs/aleo-wallet-sdk/ids"
+	"time"
+)
+
+// TrackerSource is the subset of the client used to track a transaction.
+// It is implemented by Client and Pool.
+type TrackerSource interface {
+	BlockSource
+	GetTransaction(ctx context.Context, txID ids.TransactionID) (*GetTransactionResponse, error)
+	SendTransaction(ctx context.Context, txHex string) (ids.TransactionID, error)
+}
+
+// TxEventType denotes the progress of a tracked transaction.
+type TxEventType int
+
+const (
+	// TxAccepted is emitted each time the node accepts a broadcast of the transaction.
+	TxAccepted TxEventType = iota
+	// TxSeen is emitted when the transaction is first seen in a block.
+	TxSeen
+	// TxReorged is emitted when the block containing the transaction is orphaned.
+	TxReorged
+	// TxConfirmed is emitted once the transaction has the configured number of confirmations.
+	TxConfirmed
+	// TxConflicted is emitted once another transaction spending one of its serial numbers
+	// has the configured number of confirmations.
+	TxConflicted
+	// TxDropped is emitted when the transaction is not seen in a block after every rebroadcast,
+	// that is MaxRebroadcasts+1 rebroadcast intervals, whether or not TxHex is set.
+	TxDropped
+)
+
+// String implements the stringer interface for TxEventType.
+func (t TxEventType) String() string {
+	switch t {
+	case TxAccepted:
+		return "accepted"
+	case TxSeen:
+		return "seen"
+	case TxReorged:
+		return "reorged"
+	case TxConfirmed:
+		return "confirmed"
+	case TxConflicted:
+		return "conflicted"
+	case TxDropped:
+		return "dropped"
+	}
+	return fmt.Sprintf("TxEventType(%d)", int(t))
+}
+
+// TxEvent is emitted by a Tracker.
+type TxEvent struct {
+	Type TxEventType
+	TxID ids.TransactionID
+	// Height and BlockHash locate the transaction, or the conflicting transaction for TxConflicted.
+	Height    int64
+	BlockHash ids.BlockHash
+	// Confirmations is the number of blocks from Height to the tip, inclusive.
+	Confirmations int64
+	// ConflictingTxID is the transaction that spent the serial numbers for TxConflicted.
+	ConflictingTxID ids.TransactionID
+}
+
+// TrackerConfig holds the configuration for a Tracker.
+type TrackerConfig struct {
+	// TxHex is the serialized transaction. When set, Run broadcasts it and rebroadcasts it
+	// while it is not seen in a block.
+	TxHex string
+	// SerialNumbers are the serial numbers spent by the transaction, used to detect conflicts.
+	// transaction.DecodeTransaction returns them for a serialized transaction.
+	SerialNumbers []ids.SerialNumber
+	// Confirmations is the number of confirmations after which the transaction is final. Defaults to 1.
+	Confirmations int64
+	// StartHeight is the first block height searched for the transaction.
+	// Zero means the height after the node's tip when Run starts.
+	// A transaction the node already has in a block is followed from that block instead.
+	StartHeight int64
+	// RebroadcastInterval is how long the transaction may go unseen before it is rebroadcast. Defaults to 2m.
+	RebroadcastInterval time.Duration
+	// MaxRebroadcasts is the number of rebroadcasts before the transaction is reported dropped. Defaults to 3.
+	// Without TxHex, the tracker waits the same number of intervals without rebroadcasting.
+	MaxRebroadcasts int
+	// PollInterval is the delay between checks for new blocks. Defaults to 10s.
+	PollInterval time.Duration
+	// MaxReorgDepth is the number of blocks remembered to handle reorgs. Defaults to 100.
+	MaxReorgDepth int
+	// OnError is called with errors from polling or rebroadcasting. The tracker keeps going.
+	OnError func(error)
+}
+
+// Tracker follows a transaction from broadcast until it is confirmed, conflicted or dropped.
+type Tracker struct {
+	src    TrackerSource
+	txID   ids.TransactionID
+	cfg    TrackerConfig
+	events chan TxEvent
+
+	serials     map[ids.SerialNumber]bool
+	rebroadcast int
+	tip         int64
+	// seen and conflict are the connected blocks holding the transaction and a conflicting one.
+	seen     *TxEvent
+	conflict *TxEvent
+}
+
+// NewTracker returns a Tracker for the transaction with the given ID.
+func NewTracker(src TrackerSource, txID ids.TransactionID, cfg *TrackerConfig) *Tracker {
+	t := &Tracker{
+		src:     src,
+		txID:    txID,
+		cfg:     *cfg,
+		events:  make(chan TxEvent),
+		serials: make(map[ids.SerialNumber]bool, len(cfg.SerialNumbers)),
+	}
+
+	if t.cfg.Confirmations <= 0 {
+		t.cfg.Confirmations = 1
+	}
+
+	if t.cfg.RebroadcastInterval <= 0 {
+		t.cfg.RebroadcastInterval = 2 * time.Minute
+	}
+
+	if t.cfg.MaxRebroadcasts <= 0 {
+		t.cfg.MaxRebroadcasts = 3
+	}
+
+	for _, sn := range cfg.SerialNumbers {
+		t.serials[sn] = true
+	}
+
+	return t
+}
+
+// Events returns the channel of transaction events. It is closed when Run returns.
+func (t *Tracker) Events() <-chan TxEvent {
+	return t.events
+}
+
+// Run broadcasts the transaction if TxHex is set and follows the chain until the transaction
+// is confirmed, conflicted or dropped, in which case it returns nil.
+// If the node already has the transaction in a block, it is not broadcast and Run follows it from that block,
+// so a restarted tracker picks up where it left off.
+func (t *Tracker) Run(ctx context.Context) error {
+	defer close(t.events)
+
+	res, err := t.src.GetTransaction(ctx, t.txID)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return fmt.Errorf("Run : %w", err)
+	}
+	mined := err == nil && res.Metadata.BlockHash != ""
+
+	start := t.cfg.StartHeight
+	if mined {
+		start = res.Metadata.BlockHeight
+	} else if start == 0 {
+		latest, err := t.src.LatestBlockHeight(ctx)
+		if err != nil {
+			return fmt.Errorf("Run : %w", err)
+		}
+		start = latest + 1
+	}
+	t.tip = start - 1
+
+	if t.cfg.TxHex != "" && !mined {
+		id, err := t.src.SendTransaction(ctx, t.cfg.TxHex)
+		if err != nil {
+			return fmt.Errorf("Run : %w", err)
+		}
+		if id != t.txID {
+			return fmt.Errorf("Run : node returned transaction ID %s want %s", id, t.txID)
+		}
+		if err := t.emit(ctx, TxEvent{Type: TxAccepted, TxID: t.txID}); err != nil {
+			return err
+		}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	w := NewWatcher(t.src, &WatcherConfig{
+		PollInterval:  t.cfg.PollInterval,
+		StartHeight:   start,
+		MaxReorgDepth: t.cfg.MaxReorgDepth,
+		OnError:       t.cfg.OnError,
+	})
+
+	errc := make(chan error, 1)
+	go func() { errc <- w.Run(ctx) }()
+
+	// Stop the watcher and wait for it to close its events so no goroutine outlives Run.
+	defer func() {
+		cancel()
+		for range w.Events() {
+		}
+	}()
+
+	timer := time.NewTimer(t.cfg.RebroadcastInterval)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+
+		case ev, ok := <-w.Events():
+			if !ok {
+				return <-errc
+			}
+
+			wasPending := t.pending()
+			done, err := t.block(ctx, ev)
+			if done || err != nil {
+				return err
+			}
+
+			// The rebroadcast window starts over when a reorg leaves the transaction pending again.
+			if !wasPending && t.pending() {
+				resetTimer(timer, t.cfg.RebroadcastInterval)
+			}
+
+		case <-timer.C:
+			if !t.pending() {
+				continue
+			}
+
+			if t.rebroadcast >= t.cfg.MaxRebroadcasts {
+				return t.emit(ctx, TxEvent{Type: TxDropped, TxID: t.txID})
+			}
+
+			// Without TxHex there is nothing to rebroadcast, but the transaction gets as many windows to appear.
+			t.rebroadcast++
+			if t.cfg.TxHex == "" {
+				timer.Reset(t.cfg.RebroadcastInterval)
+				continue
+			}
+
+			if _, err := t.src.SendTransaction(ctx, t.cfg.TxHex); err != nil {
+				if t.cfg.OnError != nil {
+					t.cfg.OnError(err)
+				}
+			} else if err := t.emit(ctx, TxEvent{Type: TxAccepted, TxID: t.txID}); err != nil {
+				return err
+			}
+
+			timer.Reset(t.cfg.RebroadcastInterval)
+		}
+	}
+}
+
+// block applies a block event and reports whether the transaction reached a final state.
+func (t *Tracker) block(ctx context.Context, ev BlockEvent) (bool, error) {
+	if ev.Type == BlockDisconnected {
+		t.tip = ev.Height - 1
+
+		if t.conflict != nil && t.conflict.BlockHash == ev.Hash {
+			t.conflict = nil
+		}
+
+		if t.seen != nil && t.seen.BlockHash == ev.Hash {
+			t.seen = nil
+			return false, t.emit(ctx, TxEvent{Type: TxReorged, TxID: t.txID, Height: ev.Height, BlockHash: ev.Hash})
+		}
+
+		return false, nil
+	}
+
+	t.tip = ev.Height
+
+	for _, tx := range ev.Block.Transactions.Transactions {
+		if tx.TxID == t.txID {
+			t.seen = &TxEvent{Type: TxSeen, TxID: t.txID, Height: ev.Height, BlockHash: ev.Hash}
+			if err := t.emit(ctx, t.event(*t.seen)); err != nil {
+				return false, err
+			}
+			continue
+		}
+
+		if t.conflict == nil && t.spends(tx) {
+			t.conflict = &TxEvent{Type: TxConflicted, TxID: t.txID, Height: ev.Height, BlockHash: ev.Hash, ConflictingTxID: tx.TxID}
+		}
+	}
+
+	switch {
+	case t.seen != nil && t.tip-t.seen.Height+1 >= t.cfg.Confirmations:
+		confirmed := *t.seen
+		confirmed.Type = TxConfirmed
+		return true, t.emit(ctx, t.event(confirmed))
+	case t.seen == nil && t.conflict != nil && t.tip-t.conflict.Height+1 >= t.cfg.Confirmations:
+		return true, t.emit(ctx, t.event(*t.conflict))
+	}
+
+	return false, nil
+}
+
+// pending reports whether neither the transaction nor a conflicting one is in the chain.
+func (t *Tracker) pending() bool {
+	return t.seen == nil && t.conflict == nil
+}
+
+// spends reports whether tx spends one of the tracked serial numbers.
+func (t *Tracker) spends(tx Transaction) bool {
+	for _, transition := range tx.Transitions {
+		for _, sn := range transition.SerialNumbers {
+			if t.serials[sn] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// event sets the confirmations of ev against the current tip.
+func (t *Tracker) event(ev TxEvent) TxEvent {
+	ev.Confirmations = t.tip - ev.Height + 1
+	return ev
+}
+
+func (t *Tracker) emit(ctx context.Context, ev TxEvent) error {
+	select {
+	case t.events <- ev:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func resetTimer(timer *time.Timer, d time.Duration) {
+	if !timer.Stop() {
+		select {
+		case <-timer.C:
+		default:
+		}
+	}
+	timer.Reset(d)
+}
@@ -0,0 +1,256 @@
+package rpc_test
+
+import (
+	"context"
+	"github.com/pinestreetlabs/aleo-wallet-sdk/ids"
+	"github.com/pinestreetlabs/aleo-wallet-sdk/rpc"
+	"github.com/pinestreetlabs/aleo-wallet-sdk/rpc/rpctest"
+	"testing"
+	"time"
+)
+
+const trackerTxHex = "deadbeef"
+
+func newTracker(t *testing.T, s *rpctest.Server, cfg *rpc.TrackerConfig) (*rpc.Tracker, chan error) {
+	t.Helper()
+
+	client, err := rpc.NewClient(s.Config())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg.PollInterval = 5 * time.Millisecond
+	tracker := rpc.NewTracker(client, ids.TransactionID(rpctest.NewID("at", trackerTxHex)), cfg)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+
+	errc := make(chan error, 1)
+	go func() { errc <- tracker.Run(ctx) }()
+
+	return tracker, errc
+}
+
+func expectTxEvents(t *testing.T, tracker *rpc.Tracker, want ...rpc.TxEvent) {
+	t.Helper()
+
+	for _, exp := range want {
+		ev, ok := <-tracker.Events()
+		if !ok {
+			t.Fatal("events closed")
+		}
+		if ev.Type != exp.Type || ev.Height != exp.Height || ev.BlockHash != exp.BlockHash ||
+			ev.Confirmations != exp.Confirmations || ev.ConflictingTxID != exp.ConflictingTxID {
+			t.Fatalf("got %+v want %+v", ev, exp)
+		}
+	}
+}
+
+func expectDone(t *testing.T, tracker *rpc.Tracker, errc chan error) {
+	t.Helper()
+
+	if err := <-errc; err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := <-tracker.Events(); ok {
+		t.Fatal("events not closed")
+	}
+}
+
+func trackedBlock(txs ...rpc.Transaction) rpc.Block {
+	return rpc.Block{Transactions: rpc.Transactions{Transactions: txs}}
+}
+
+func TestTrackerConfirmed(t *testing.T) {
+	s := rpctest.NewServer()
+	defer s.Close()
+
+	s.AddBlocks(2)
+
+	tracker, errc := newTracker(t, s, &rpc.TrackerConfig{
+		TxHex:               trackerTxHex,
+		Confirmations:       3,
+		RebroadcastInterval: time.Hour,
+	})
+
+	expectTxEvents(t, tracker, rpc.TxEvent{Type: rpc.TxAccepted})
+
+	txID := ids.TransactionID(rpctest.NewID("at", trackerTxHex))
+	b2 := s.AddBlock(trackedBlock(rpc.Transaction{TxID: txID}))
+	expectTxEvents(t, tracker, rpc.TxEvent{Type: rpc.TxSeen, Height: 2, BlockHash: b2.BlockHash, Confirmations: 1})
+
+	// A reorg orphans the block, then the transaction is mined again.
+	s.Rewind(1)
+	s.AddBlocks(2)
+	c4 := s.AddBlock(trackedBlock(rpc.Transaction{TxID: txID}))
+	expectTxEvents(t, tracker,
+		rpc.TxEvent{Type: rpc.TxReorged, Height: 2, BlockHash: b2.BlockHash},
+		rpc.TxEvent{Type: rpc.TxSeen, Height: 4, BlockHash: c4.BlockHash, Confirmations: 1},
+	)
+
+	s.AddBlocks(2)
+	expectTxEvents(t, tracker, rpc.TxEvent{Type: rpc.TxConfirmed, Height: 4, BlockHash: c4.BlockHash, Confirmations: 3})
+	expectDone(t, tracker, errc)
+
+	if sent := s.SentTransactions(); len(sent) != 1 {
+		t.Fatalf("got %d broadcasts want %d", len(sent), 1)
+	}
+}
+
+func TestTrackerRestart(t *testing.T) {
+	s := rpctest.NewServer()
+	defer s.Close()
+
+	s.AddBlocks(2)
+	txID := ids.TransactionID(rpctest.NewID("at", trackerTxHex))
+	b2 := s.AddBlock(trackedBlock(rpc.Transaction{TxID: txID}))
+	s.AddBlocks(1)
+
+	// The node already has the transaction, so it is followed from its block without a broadcast.
+	tracker, errc := newTracker(t, s, &rpc.TrackerConfig{
+		TxHex:               trackerTxHex,
+		Confirmations:       3,
+		RebroadcastInterval: time.Hour,
+	})
+
+	expectTxEvents(t, tracker, rpc.TxEvent{Type: rpc.TxSeen, Height: 2, BlockHash: b2.BlockHash, Confirmations: 1})
+
+	s.AddBlocks(1)
+	expectTxEvents(t, tracker, rpc.TxEvent{Type: rpc.TxConfirmed, Height: 2, BlockHash: b2.BlockHash, Confirmations: 3})
+	expectDone(t, tracker, errc)
+
+	if sent := s.SentTransactions(); len(sent) != 0 {
+		t.Fatalf("got %d broadcasts want %d", len(sent), 0)
+	}
+}
+
+func TestTrackerReorgRebroadcast(t *testing.T) {
+	s := rpctest.NewServer()
+	defer s.Close()
+
+	s.AddBlocks(2)
+
+	tracker, errc := newTracker(t, s, &rpc.TrackerConfig{
+		TxHex:               trackerTxHex,
+		Confirmations:       2,
+		RebroadcastInterval: 200 * time.Millisecond,
+		MaxRebroadcasts:     1,
+	})
+
+	accepted := rpc.TxEvent{Type: rpc.TxAccepted}
+	expectTxEvents(t, tracker, accepted)
+
+	txID := ids.TransactionID(rpctest.NewID("at", trackerTxHex))
+	b2 := s.AddBlock(trackedBlock(rpc.Transaction{TxID: txID}))
+	expectTxEvents(t, tracker, rpc.TxEvent{Type: rpc.TxSeen, Height: 2, BlockHash: b2.BlockHash, Confirmations: 1})
+
+	// Let the rebroadcast timer fire while the transaction is in a block.
+	time.Sleep(250 * time.Millisecond)
+
+	// The reorg leaves the transaction pending, so the timer starts over and it is rebroadcast.
+	s.Rewind(1)
+	s.AddBlocks(2)
+	expectTxEvents(t, tracker, rpc.TxEvent{Type: rpc.TxReorged, Height: 2, BlockHash: b2.BlockHash}, accepted)
+
+	c4 := s.AddBlock(trackedBlock(rpc.Transaction{TxID: txID}))
+	s.AddBlocks(1)
+	expectTxEvents(t, tracker,
+		rpc.TxEvent{Type: rpc.TxSeen, Height: 4, BlockHash: c4.BlockHash, Confirmations: 1},
+		rpc.TxEvent{Type: rpc.TxConfirmed, Height: 4, BlockHash: c4.BlockHash, Confirmations: 2},
+	)
+	expectDone(t, tracker, errc)
+
+	if sent := s.SentTransactions(); len(sent) != 2 {
+		t.Fatalf("got %d broadcasts want %d", len(sent), 2)
+	}
+}
+
+func TestTrackerConflicted(t *testing.T) {
+	s := rpctest.NewServer()
+	defer s.Close()
+
+	s.AddBlocks(1)
+
+	serial := ids.SerialNumber(rpctest.NewID("sn", "spent"))
+	tracker, errc := newTracker(t, s, &rpc.TrackerConfig{
+		SerialNumbers:       []ids.SerialNumber{serial},
+		Confirmations:       2,
+		StartHeight:         1,
+		RebroadcastInterval: time.Hour,
+	})
+
+	other := rpc.Transaction{
+		TxID:        ids.TransactionID(rpctest.NewID("at", "other")),
+		Transitions: []rpc.Transition{{SerialNumbers: []ids.SerialNumber{ids.SerialNumber(rpctest.NewID("sn", "other")), serial}}},
+	}
+
+	b1 := s.AddBlock(trackedBlock(other))
+	s.AddBlocks(1)
+
+	expectTxEvents(t, tracker, rpc.TxEvent{Type: rpc.TxConflicted, Height: 1, BlockHash: b1.BlockHash, Confirmations: 2, ConflictingTxID: other.TxID})
+	expectDone(t, tracker, errc)
+
+	if sent := s.SentTransactions(); len(sent) != 0 {
+		t.Fatalf("got %d broadcasts want %d", len(sent), 0)
+	}
+}
+
+func TestTrackerDropped(t *testing.T) {
+	s := rpctest.NewServer()
+	defer s.Close()
+
+	s.AddBlocks(1)
+
+	tracker, errc := newTracker(t, s, &rpc.TrackerConfig{
+		TxHex:               trackerTxHex,
+		RebroadcastInterval: 20 * time.Millisecond,
+		MaxRebroadcasts:     2,
+	})
+
+	accepted := rpc.TxEvent{Type: rpc.TxAccepted}
+	expectTxEvents(t, tracker, accepted, accepted, accepted, rpc.TxEvent{Type: rpc.TxDropped})
+	expectDone(t, tracker, errc)
+
+	if sent := s.SentTransactions(); len(sent) != 3 {
+		t.Fatalf("got %d broadcasts want %d", len(sent), 3)
+	}
+}
+
+func TestTrackerDroppedWithoutTxHex(t *testing.T) {
+	s := rpctest.NewServer()
+	defer s.Close()
+
+	s.AddBlocks(1)
+
+	// Without TxHex the transaction still gets MaxRebroadcasts+1 intervals to appear.
+	start := time.Now()
+	tracker, errc := newTracker(t, s, &rpc.TrackerConfig{
+		RebroadcastInterval: 30 * time.Millisecond,
+		MaxRebroadcasts:     2,
+	})
+
+	expectTxEvents(t, tracker, rpc.TxEvent{Type: rpc.TxDropped})
+	expectDone(t, tracker, errc)
+
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Fatalf("dropped after %s want at least %s", elapsed, 90*time.Millisecond)
+	}
+	if sent := s.SentTransactions(); len(sent) != 0 {
+		t.Fatalf("got %d broadcasts want %d", len(sent), 0)
+	}
+}
+
+func TestTrackerWrongID(t *testing.T) {
+	s := rpctest.NewServer()
+	defer s.Close()
+
+	client, err := rpc.NewClient(s.Config())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tracker := rpc.NewTracker(client, ids.TransactionID(rpctest.NewID("at", "other")), &rpc.TrackerConfig{TxHex: trackerTxHex})
+	if err := tracker.Run(context.Background()); err == nil {
+		t.Fatal("expected err")
+	}
+}
@@ -0,0 +1,153 @@
+package rpc
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+var errNoCerts = errors.New("no certificates found")
+
+const (
+	defaultMaxResponseSize = 64 << 20
+	// maxErrorBody is how much of a non-2xx response body is kept in an HTTPError.
+	maxErrorBody = 4 << 10
+	// maxDrain is how much of an unread response body is discarded so its connection can be reused.
+	maxDrain = 64 << 10
+)
+
+// endpoint returns the URL requests are posted to, built from Host and Port when URL is empty.
+func (cfg *Config) endpoint() (*url.URL, error) {
+	if cfg.URL == "" {
+		return &url.URL{Scheme: "http", Host: net.JoinHostPort(cfg.Host, cfg.Port)}, nil
+	}
+
+	u, err := url.Parse(cfg.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("unsupported url scheme %q", u.Scheme)
+	}
+
+	if u.Host == "" {
+		return nil, fmt.Errorf("missing host in url %q", cfg.URL)
+	}
+
+	return u, nil
+}
+
+// transport returns the HTTP transport for the configured connection, TLS and proxy options.
+func (cfg *Config) transport() (http.RoundTripper, error) {
+	if cfg.Transport != nil {
+		return cfg.Transport, nil
+	}
+
+	t := http.DefaultTransport.(*http.Transport).Clone()
+
+	keepAlive := cfg.KeepAlive
+	if keepAlive == 0 {
+		keepAlive = 30 * time.Second
+	}
+	t.DialContext = (&net.Dialer{Timeout: 30 * time.Second, KeepAlive: keepAlive}).DialContext
+
+	t.MaxIdleConnsPerHost = 16
+	if cfg.MaxIdleConns > 0 {
+		t.MaxIdleConns = cfg.MaxIdleConns
+	}
+	if cfg.MaxIdleConnsPerHost > 0 {
+		t.MaxIdleConnsPerHost = cfg.MaxIdleConnsPerHost
+	}
+	if cfg.MaxConnsPerHost > 0 {
+		t.MaxConnsPerHost = cfg.MaxConnsPerHost
+	}
+	if cfg.IdleConnTimeout > 0 {
+		t.IdleConnTimeout = cfg.IdleConnTimeout
+	}
+
+	// Compression is negotiated by the client itself so it also applies to custom transports.
+	t.DisableCompression = true
+
+	if cfg.Proxy != "" {
+		proxy, err := url.Parse(cfg.Proxy)
+		if err != nil {
+			return nil, fmt.Errorf("proxy : %w", err)
+		}
+		t.Proxy = http.ProxyURL(proxy)
+	}
+
+	if cfg.CAFile == "" && cfg.CertFile == "" && cfg.KeyFile == "" {
+		return t, nil
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if cfg.CAFile != "" {
+		pem, err := ioutil.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("ca file : %w", err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("ca file : %w", errNoCerts)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if cfg.CertFile != "" || cfg.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("client certificate : %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	t.TLSClientConfig = tlsConfig
+
+	return t, nil
+}
+
+// responseBody reads a possibly decompressed response and closes the underlying body.
+type responseBody struct {
+	io.Reader
+	body io.ReadCloser
+}
+
+// Close discards what is left of a small response so the connection can be reused, then closes it.
+func (b *responseBody) Close() error {
+	io.CopyN(ioutil.Discard, b.body, maxDrain)
+	return b.body.Close()
+}
+
+// limitReader fails with ErrResponseTooLarge once more than n bytes are read.
+type limitReader struct {
+	r io.Reader
+	n int64
+}
+
+func (l *limitReader) Read(p []byte) (int, error) {
+	if l.n < 0 {
+		return 0, ErrResponseTooLarge
+	}
+
+	if int64(len(p)) > l.n+1 {
+		p = p[:l.n+1]
+	}
+
+	n, err := l.r.Read(p)
+	l.n -= int64(n)
+	if l.n < 0 {
+		return n, ErrResponseTooLarge
+	}
+
+	return n, err
+}
@@ -0,0 +1,319 @@
+package rpc
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func heightHandler(w http.ResponseWriter, r *http.Request) {
+	w.Write([]byte(`{"jsonrpc":"2.0","result":42,"id":""}`))
+}
+
+func writePEM(t *testing.T, name, typ string, der []byte) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	if err := ioutil.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: typ, Bytes: der}), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func TestURLConfig(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, password, _ := r.BasicAuth()
+		if r.URL.Path != "/rpc/v1" || user != "alice" || password != "secret" || r.Header.Get("X-Api-Key") != "key" {
+			http.Error(w, "unexpected request", http.StatusBadRequest)
+			return
+		}
+		heightHandler(w, r)
+	}))
+	defer srv.Close()
+
+	client, err := NewClient(&Config{
+		URL:     "http://alice:secret@" + srv.Listener.Addr().String() + "/rpc/v1",
+		Headers: http.Header{"x-api-key": []string{"key"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.LatestBlockHeight(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCAFile(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(heightHandler))
+	defer srv.Close()
+
+	client, err := NewClient(&Config{URL: srv.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.LatestBlockHeight(context.Background()); !errors.Is(err, ErrTransport) {
+		t.Fatalf("got %v want %v", err, ErrTransport)
+	}
+
+	client, err = NewClient(&Config{
+		URL:    srv.URL,
+		CAFile: writePEM(t, "ca.pem", "CERTIFICATE", srv.Certificate().Raw),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.LatestBlockHeight(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMutualTLS(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "nemean"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(cert)
+
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(heightHandler))
+	srv.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs}
+	srv.StartTLS()
+	defer srv.Close()
+
+	caFile := writePEM(t, "ca.pem", "CERTIFICATE", srv.Certificate().Raw)
+
+	client, err := NewClient(&Config{URL: srv.URL, CAFile: caFile})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.LatestBlockHeight(context.Background()); !errors.Is(err, ErrTransport) {
+		t.Fatalf("got %v want %v", err, ErrTransport)
+	}
+
+	client, err = NewClient(&Config{
+		URL:      srv.URL,
+		CAFile:   caFile,
+		CertFile: writePEM(t, "cert.pem", "CERTIFICATE", der),
+		KeyFile:  writePEM(t, "key.pem", "EC PRIVATE KEY", keyDER),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.LatestBlockHeight(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestProxy(t *testing.T) {
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Host != "node.invalid:3032" {
+			http.Error(w, "unexpected host", http.StatusBadGateway)
+			return
+		}
+		heightHandler(w, r)
+	}))
+	defer proxy.Close()
+
+	client, err := NewClient(&Config{Host: "node.invalid", Port: "3032", Proxy: proxy.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.LatestBlockHeight(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestCustomTransport(t *testing.T) {
+	var calls int
+	cfg := newTestConfig(t, heightHandler)
+	cfg.Transport = roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		calls++
+		return http.DefaultTransport.RoundTrip(r)
+	})
+
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.LatestBlockHeight(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if calls != 1 {
+		t.Fatalf("got %d want %d", calls, 1)
+	}
+}
+
+func TestInvalidTransportConfig(t *testing.T) {
+	tests := map[string]*Config{
+		"scheme":      {URL: "ftp://node:3032"},
+		"host":        {URL: "http:///rpc"},
+		"ca file":     {URL: "https://node:3032", CAFile: filepath.Join(t.TempDir(), "missing.pem")},
+		"client cert": {URL: "https://node:3032", CertFile: "cert.pem"},
+		"proxy":       {Host: "node", Port: "3032", Proxy: "http://[::1"},
+	}
+
+	for name, cfg := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := NewClient(cfg); err == nil {
+				t.Fatal("expected err")
+			}
+		})
+	}
+}
+
+func TestResponseTooLarge(t *testing.T) {
+	cfg := newTestConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"jsonrpc":"2.0","result":"` + strings.Repeat("a", 1024) + `","id":""}`))
+	})
+	cfg.MaxResponseSize = 512
+
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = client.LatestBlockHash(context.Background())
+	if !errors.Is(err, ErrResponseTooLarge) || !errors.Is(err, ErrTransport) {
+		t.Fatalf("got %v want %v", err, ErrResponseTooLarge)
+	}
+
+	if _, err := client.GetBlocks(context.Background(), 1, 2); !errors.Is(err, ErrResponseTooLarge) {
+		t.Fatalf("got %v want %v", err, ErrResponseTooLarge)
+	}
+}
+
+func TestGzipResponse(t *testing.T) {
+	cfg := newTestConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept-Encoding") != "gzip" {
+			heightHandler(w, r)
+			return
+		}
+
+		var buf bytes.Buffer
+		gz := gzip.NewWriter(&buf)
+		gz.Write([]byte(`{"jsonrpc":"2.0","result":7,"id":""}`))
+		gz.Close()
+
+		w.Header().Set("Content-Encoding", "gzip")
+		w.Write(buf.Bytes())
+	})
+
+	for _, disable := range []bool{false, true} {
+		cfg.DisableCompression = disable
+
+		client, err := NewClient(cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		height, err := client.LatestBlockHeight(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := int64(7)
+		if disable {
+			want = 42
+		}
+
+		if height != want {
+			t.Fatalf("got %d want %d", height, want)
+		}
+	}
+}
+
+func TestConnectionReuse(t *testing.T) {
+	var conns int32
+	var calls int32
+
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1)%2 == 0 {
+			http.Error(w, strings.Repeat("unavailable ", 1024), http.StatusServiceUnavailable)
+			return
+		}
+		heightHandler(w, r)
+	}))
+	srv.Config.ConnState = func(c net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			atomic.AddInt32(&conns, 1)
+		}
+	}
+	srv.Start()
+	defer srv.Close()
+
+	client, err := NewClient(&Config{URL: srv.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 6; i++ {
+		_, err := client.LatestBlockHeight(context.Background())
+
+		var httpErr *HTTPError
+		if i%2 == 1 && (!errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusServiceUnavailable || len(httpErr.Body) > maxErrorBody) {
+			t.Fatalf("got %v want %d", err, http.StatusServiceUnavailable)
+		}
+	}
+
+	if n := atomic.LoadInt32(&conns); n != 1 {
+		t.Fatalf("got %d connections want %d", n, 1)
+	}
+}
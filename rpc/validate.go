@@ -0,0 +1,140 @@
+package rpc
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// ErrInvalidBlock is returned when a block or header does not follow from its parent.
+var ErrInvalidBlock = errors.New("invalid block")
+
+// ValidatorConfig holds the configuration for a HeaderValidator.
+type ValidatorConfig struct {
+	// MaxFutureTime is how far a timestamp may be ahead of the local clock. Defaults to 2h.
+	MaxFutureTime time.Duration
+	// MaxTargetAdjustment is the factor by which the difficulty target may change from one block to the next. Defaults to 4.
+	MaxTargetAdjustment int64
+	// Now returns the local time. Defaults to time.Now.
+	Now func() time.Time
+}
+
+// HeaderValidator checks that blocks and headers returned by a node form a chain.
+// Headers without a difficulty target, such as those mapped from REST nodes, skip the difficulty checks.
+type HeaderValidator struct {
+	cfg ValidatorConfig
+}
+
+// NewHeaderValidator returns a HeaderValidator.
+func NewHeaderValidator(cfg *ValidatorConfig) *HeaderValidator {
+	v := &HeaderValidator{cfg: *cfg}
+
+	if v.cfg.MaxFutureTime <= 0 {
+		v.cfg.MaxFutureTime = 2 * time.Hour
+	}
+
+	if v.cfg.MaxTargetAdjustment <= 1 {
+		v.cfg.MaxTargetAdjustment = 4
+	}
+
+	if v.cfg.Now == nil {
+		v.cfg.Now = time.Now
+	}
+
+	return v
+}
+
+// ValidateBlocks checks that blocks, lowest height first, form a chain under the default ValidatorConfig.
+func ValidateBlocks(blocks []Block) error {
+	return NewHeaderValidator(&ValidatorConfig{}).ValidateBlocks(blocks)
+}
+
+// ValidateHeaders checks that headers, lowest height first, form a chain under the default ValidatorConfig.
+func ValidateHeaders(headers []BlockHeader) error {
+	return NewHeaderValidator(&ValidatorConfig{}).ValidateHeaders(headers)
+}
+
+// ValidateBlocks checks that blocks, lowest height first, form a chain.
+func (v *HeaderValidator) ValidateBlocks(blocks []Block) error {
+	var parent *Block
+	for i := range blocks {
+		if err := v.CheckBlock(parent, &blocks[i]); err != nil {
+			return err
+		}
+		parent = &blocks[i]
+	}
+
+	return nil
+}
+
+// ValidateHeaders checks that headers, lowest height first, form a chain.
+// Headers carry no hashes, so only heights, timestamps and difficulty targets are checked.
+func (v *HeaderValidator) ValidateHeaders(headers []BlockHeader) error {
+	var parent *BlockHeader
+	for i := range headers {
+		if err := v.CheckHeader(parent, &headers[i]); err != nil {
+			return err
+		}
+		parent = &headers[i]
+	}
+
+	return nil
+}
+
+// CheckBlock checks that block links to parent and that its header follows from the parent's.
+// A nil parent only checks the block on its own.
+func (v *HeaderValidator) CheckBlock(parent, block *Block) error {
+	if parent == nil {
+		return v.CheckHeader(nil, &block.BlockHeader)
+	}
+
+	if block.PreviousBlockHash != parent.BlockHash {
+		return fmt.Errorf("%w : height %d : previous block hash %s want %s",
+			ErrInvalidBlock, block.BlockHeader.Metadata.Height, block.PreviousBlockHash, parent.BlockHash)
+	}
+
+	return v.CheckHeader(&parent.BlockHeader, &block.BlockHeader)
+}
+
+// CheckHeader checks that header follows from parent.
+// A nil parent only checks the header on its own.
+func (v *HeaderValidator) CheckHeader(parent, header *BlockHeader) error {
+	meta := &header.Metadata
+
+	if max := v.cfg.Now().Add(v.cfg.MaxFutureTime).Unix(); meta.Timestamp > max {
+		return fmt.Errorf("%w : height %d : timestamp %d is in the future", ErrInvalidBlock, meta.Height, meta.Timestamp)
+	}
+
+	if meta.DifficultyTarget.Sign() < 0 {
+		return fmt.Errorf("%w : height %d : negative difficulty target", ErrInvalidBlock, meta.Height)
+	}
+
+	if parent == nil {
+		return nil
+	}
+
+	prev := &parent.Metadata
+
+	if meta.Height != prev.Height+1 {
+		return fmt.Errorf("%w : height %d follows height %d", ErrInvalidBlock, meta.Height, prev.Height)
+	}
+
+	if meta.Timestamp < prev.Timestamp {
+		return fmt.Errorf("%w : height %d : timestamp %d before parent %d", ErrInvalidBlock, meta.Height, meta.Timestamp, prev.Timestamp)
+	}
+
+	if meta.DifficultyTarget.Sign() == 0 || prev.DifficultyTarget.Sign() == 0 {
+		return nil
+	}
+
+	// Each target must be within a factor of MaxTargetAdjustment of its parent's.
+	factor := big.NewInt(v.cfg.MaxTargetAdjustment)
+	if new(big.Int).Mul(&prev.DifficultyTarget, factor).Cmp(&meta.DifficultyTarget) < 0 ||
+		new(big.Int).Mul(&meta.DifficultyTarget, factor).Cmp(&prev.DifficultyTarget) < 0 {
+		return fmt.Errorf("%w : height %d : difficulty target %s too far from parent %s",
+			ErrInvalidBlock, meta.Height, meta.DifficultyTarget.String(), prev.DifficultyTarget.String())
+	}
+
+	return nil
+}
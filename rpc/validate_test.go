@@ -0,0 +1,98 @@
+package rpc
+
+import (
+	"errors"
+	"github.com/pinestreetlabs/aleo-wallet-sdk/ids"
+	"testing"
+	"time"
+)
+
+// testChain returns n linked blocks from height 0, 20 seconds apart, with the given difficulty target.
+func testChain(t *testing.T, n int, target int64) []Block {
+	t.Helper()
+
+	var blocks []Block
+	var prev ids.BlockHash
+	for i := 0; i < n; i++ {
+		var b [ids.Size]byte
+		b[0] = byte(i + 1)
+		hash, err := ids.NewBlockHash(b[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		block := Block{BlockHash: hash, PreviousBlockHash: prev}
+		block.BlockHeader.Metadata.Height = int64(i)
+		block.BlockHeader.Metadata.Timestamp = 1637790000 + 20*int64(i)
+		block.BlockHeader.Metadata.DifficultyTarget.SetInt64(target)
+
+		blocks = append(blocks, block)
+		prev = hash
+	}
+
+	return blocks
+}
+
+func TestValidateBlocks(t *testing.T) {
+	if err := ValidateBlocks(testChain(t, 5, 1000)); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ValidateBlocks(testChain(t, 5, 0)); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ValidateBlocks(nil); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestValidateBlocksInvalid(t *testing.T) {
+	tests := map[string]func(blocks []Block){
+		"unlinked":   func(blocks []Block) { blocks[3].PreviousBlockHash = blocks[1].BlockHash },
+		"height gap": func(blocks []Block) { blocks[3].BlockHeader.Metadata.Height = 4 },
+		"time reversed": func(blocks []Block) {
+			blocks[3].BlockHeader.Metadata.Timestamp = blocks[1].BlockHeader.Metadata.Timestamp
+		},
+		"future":          func(blocks []Block) { blocks[3].BlockHeader.Metadata.Timestamp = time.Now().Add(3 * time.Hour).Unix() },
+		"target raised":   func(blocks []Block) { blocks[3].BlockHeader.Metadata.DifficultyTarget.SetInt64(4001) },
+		"target lowered":  func(blocks []Block) { blocks[3].BlockHeader.Metadata.DifficultyTarget.SetInt64(249) },
+		"target negative": func(blocks []Block) { blocks[0].BlockHeader.Metadata.DifficultyTarget.SetInt64(-1) },
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			blocks := testChain(t, 5, 1000)
+			tc(blocks)
+
+			if err := ValidateBlocks(blocks); !errors.Is(err, ErrInvalidBlock) {
+				t.Fatalf("got %v want %v", err, ErrInvalidBlock)
+			}
+		})
+	}
+}
+
+func TestValidateHeaders(t *testing.T) {
+	blocks := testChain(t, 3, 1000)
+
+	var headers []BlockHeader
+	for _, block := range blocks {
+		headers = append(headers, block.BlockHeader)
+	}
+
+	v := NewHeaderValidator(&ValidatorConfig{MaxTargetAdjustment: 2})
+	if err := v.ValidateHeaders(headers); err != nil {
+		t.Fatal(err)
+	}
+
+	headers[2].Metadata.DifficultyTarget.SetInt64(2001)
+	if err := v.ValidateHeaders(headers); !errors.Is(err, ErrInvalidBlock) {
+		t.Fatalf("got %v want %v", err, ErrInvalidBlock)
+	}
+
+	// A clock far behind the chain makes every header look like it is from the future.
+	v = NewHeaderValidator(&ValidatorConfig{Now: func() time.Time { return time.Unix(0, 0) }})
+	if err := v.ValidateHeaders(headers[:1]); !errors.Is(err, ErrInvalidBlock) {
+		t.Fatalf("got %v want %v", err, ErrInvalidBlock)
+	}
+}
@@ -0,0 +1,274 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/pinestreetlabs/aleo-wallet-sdk/ids"
+	"time"
+)
+
+// ErrReorgTooDeep is returned by Watcher.Run when a reorg reaches below the blocks the watcher remembers.
+var ErrReorgTooDeep = errors.New("reorg deeper than watcher history")
+
+// BlockSource is the subset of the client used to follow the chain.
+// It is implemented by Client and Pool.
+type BlockSource interface {
+	LatestBlockHeight(ctx context.Context) (int64, error)
+	GetBlock(ctx context.Context, blockNumber int64) (*Block, error)
+	GetBlockHash(ctx context.Context, height int64) (ids.BlockHash, error)
+}
+
+// BlockEventType denotes whether a block joined or left the best chain.
+type BlockEventType int
+
+const (
+	// BlockConnected is emitted when a block extends the best chain.
+	BlockConnected BlockEventType = iota
+	// BlockDisconnected is emitted when a previously connected block is orphaned by a reorg.
+	BlockDisconnected
+)
+
+// String implements the stringer interface for BlockEventType.
+func (t BlockEventType) String() string {
+	switch t {
+	case BlockConnected:
+		return "connected"
+	case BlockDisconnected:
+		return "disconnected"
+	}
+	return fmt.Sprintf("BlockEventType(%d)", int(t))
+}
+
+// BlockEvent is emitted by a Watcher.
+type BlockEvent struct {
+	Type   BlockEventType
+	Height int64
+	Hash   ids.BlockHash
+	// Block is nil when disconnecting the block the watcher resumed from.
+	Block *Block
+}
+
+// WatcherConfig holds the configuration for a Watcher.
+type WatcherConfig struct {
+	// PollInterval is the delay between checks for new blocks. Defaults to 10s.
+	PollInterval time.Duration
+	// StartHeight is the first block height to emit.
+	StartHeight int64
+	// ResumeHash is the hash of the block at StartHeight-1, which the consumer already processed.
+	// When set, the block at StartHeight must link to it, otherwise it is disconnected first.
+	ResumeHash ids.BlockHash
+	// MaxReorgDepth is the number of connected blocks remembered to handle reorgs. Defaults to 100.
+	MaxReorgDepth int
+	// OnError is called with errors from polling the node. The watcher retries on the next poll.
+	OnError func(error)
+	// Validator, when set, checks each block against its parent before it is connected.
+	// Invalid blocks are reported to OnError with ErrInvalidBlock and retried on the next poll.
+	Validator *HeaderValidator
+}
+
+// Watcher follows the best chain and streams connected and disconnected blocks in order.
+type Watcher struct {
+	src    BlockSource
+	cfg    WatcherConfig
+	events chan BlockEvent
+
+	// chain holds the most recently connected blocks, lowest height first.
+	chain []BlockEvent
+	// unanchored is set while the watcher may rewind below its history, which
+	// is the case until history is trimmed if no ResumeHash was given.
+	unanchored bool
+}
+
+// NewWatcher returns a Watcher reading blocks from src.
+func NewWatcher(src BlockSource, cfg *WatcherConfig) *Watcher {
+	w := &Watcher{
+		src:        src,
+		cfg:        *cfg,
+		events:     make(chan BlockEvent),
+		unanchored: cfg.ResumeHash == "",
+	}
+
+	if w.cfg.PollInterval <= 0 {
+		w.cfg.PollInterval = 10 * time.Second
+	}
+
+	if w.cfg.MaxReorgDepth <= 0 {
+		w.cfg.MaxReorgDepth = 100
+	}
+
+	if cfg.ResumeHash != "" {
+		w.chain = append(w.chain, BlockEvent{
+			Type:   BlockConnected,
+			Height: cfg.StartHeight - 1,
+			Hash:   cfg.ResumeHash,
+		})
+	}
+
+	return w
+}
+
+// Events returns the channel of block events. It is closed when Run returns.
+func (w *Watcher) Events() <-chan BlockEvent {
+	return w.events
+}
+
+// Run polls the node until the context is done or the chain cannot be followed.
+func (w *Watcher) Run(ctx context.Context) error {
+	defer close(w.events)
+
+	ticker := time.NewTicker(w.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := w.poll(ctx); err != nil {
+			if errors.Is(err, ErrReorgTooDeep) || ctx.Err() != nil {
+				return err
+			}
+			if w.cfg.OnError != nil {
+				w.cfg.OnError(err)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// tip returns the height and hash of the last connected block.
+func (w *Watcher) tip() (int64, ids.BlockHash) {
+	if len(w.chain) == 0 {
+		return w.cfg.StartHeight - 1, ""
+	}
+	top := w.chain[len(w.chain)-1]
+	return top.Height, top.Hash
+}
+
+// poll connects every block up to the node's latest height.
+func (w *Watcher) poll(ctx context.Context) error {
+	latest, err := w.src.LatestBlockHeight(ctx)
+	if err != nil {
+		return err
+	}
+
+	// A node behind our tip is either lagging or mid-reorg; wait for it to catch up.
+	if height, hash := w.tip(); hash != "" && height <= latest {
+		current, err := w.src.GetBlockHash(ctx, height)
+		if err != nil {
+			return err
+		}
+		if current != hash {
+			if err := w.rewind(ctx); err != nil {
+				return err
+			}
+		}
+	}
+
+	for {
+		height, hash := w.tip()
+		if height >= latest {
+			return nil
+		}
+
+		block, err := w.src.GetBlock(ctx, height+1)
+		if err != nil {
+			return err
+		}
+
+		if hash != "" && block.PreviousBlockHash != hash {
+			if err := w.rewind(ctx); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := w.validate(height+1, block); err != nil {
+			return err
+		}
+
+		if err := w.connect(ctx, height+1, block); err != nil {
+			return err
+		}
+	}
+}
+
+// connect appends a block to the chain and emits it.
+func (w *Watcher) connect(ctx context.Context, height int64, block *Block) error {
+	ev := BlockEvent{
+		Type:   BlockConnected,
+		Height: height,
+		Hash:   block.BlockHash,
+		Block:  block,
+	}
+
+	if err := w.emit(ctx, ev); err != nil {
+		return err
+	}
+
+	w.chain = append(w.chain, ev)
+	if len(w.chain) > w.cfg.MaxReorgDepth {
+		w.chain = w.chain[len(w.chain)-w.cfg.MaxReorgDepth:]
+		w.unanchored = false
+	}
+
+	return nil
+}
+
+// validate checks a block fetched at height against the connected tip.
+func (w *Watcher) validate(height int64, block *Block) error {
+	if w.cfg.Validator == nil {
+		return nil
+	}
+
+	if block.BlockHeader.Metadata.Height != height {
+		return fmt.Errorf("%w : got height %d want %d", ErrInvalidBlock, block.BlockHeader.Metadata.Height, height)
+	}
+
+	var parent *Block
+	if len(w.chain) > 0 {
+		parent = w.chain[len(w.chain)-1].Block
+	}
+
+	return w.cfg.Validator.CheckBlock(parent, block)
+}
+
+// rewind disconnects blocks until the watcher's tip is back on the node's best chain.
+func (w *Watcher) rewind(ctx context.Context) error {
+	for {
+		top := w.chain[len(w.chain)-1]
+		top.Type = BlockDisconnected
+
+		if err := w.emit(ctx, top); err != nil {
+			return err
+		}
+		w.chain = w.chain[:len(w.chain)-1]
+
+		if len(w.chain) == 0 {
+			if w.unanchored {
+				return nil
+			}
+			return fmt.Errorf("%w : below height %d", ErrReorgTooDeep, top.Height)
+		}
+
+		height, hash := w.tip()
+		current, err := w.src.GetBlockHash(ctx, height)
+		if err != nil {
+			return err
+		}
+		if current == hash {
+			return nil
+		}
+	}
+}
+
+func (w *Watcher) emit(ctx context.Context, ev BlockEvent) error {
+	select {
+	case w.events <- ev:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
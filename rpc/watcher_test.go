@@ -0,0 +1,152 @@
+package rpc_test
+
+import (
+	"context"
+	"errors"
+	"github.com/pinestreetlabs/aleo-wallet-sdk/ids"
+	"github.com/pinestreetlabs/aleo-wallet-sdk/rpc"
+	"github.com/pinestreetlabs/aleo-wallet-sdk/rpc/rpctest"
+	"testing"
+	"time"
+)
+
+func newWatcher(t *testing.T, s *rpctest.Server, cfg *rpc.WatcherConfig) (*rpc.Watcher, context.CancelFunc) {
+	t.Helper()
+
+	client, err := rpc.NewClient(s.Config())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg.PollInterval = 5 * time.Millisecond
+	w := rpc.NewWatcher(client, cfg)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	go w.Run(ctx)
+
+	return w, cancel
+}
+
+func expectEvents(t *testing.T, w *rpc.Watcher, want ...rpc.BlockEvent) {
+	t.Helper()
+
+	for _, exp := range want {
+		ev, ok := <-w.Events()
+		if !ok {
+			t.Fatal("events closed")
+		}
+		if ev.Type != exp.Type || ev.Height != exp.Height || ev.Hash != exp.Hash {
+			t.Fatalf("got %s %d %s want %s %d %s", ev.Type, ev.Height, ev.Hash, exp.Type, exp.Height, exp.Hash)
+		}
+	}
+}
+
+func connected(b rpc.Block) rpc.BlockEvent {
+	return rpc.BlockEvent{Type: rpc.BlockConnected, Height: b.BlockHeader.Metadata.Height, Hash: b.BlockHash}
+}
+
+func disconnected(b rpc.Block) rpc.BlockEvent {
+	return rpc.BlockEvent{Type: rpc.BlockDisconnected, Height: b.BlockHeader.Metadata.Height, Hash: b.BlockHash}
+}
+
+func TestWatcherReorg(t *testing.T) {
+	s := rpctest.NewServer()
+	defer s.Close()
+
+	b0 := s.AddBlock(rpc.Block{})
+	b1 := s.AddBlock(rpc.Block{})
+	b2 := s.AddBlock(rpc.Block{})
+
+	w, cancel := newWatcher(t, s, &rpc.WatcherConfig{})
+	defer cancel()
+
+	expectEvents(t, w, connected(b0), connected(b1), connected(b2))
+
+	b3 := s.AddBlock(rpc.Block{})
+	expectEvents(t, w, connected(b3))
+
+	s.Rewind(1)
+	c2 := s.AddBlock(rpc.Block{})
+	c3 := s.AddBlock(rpc.Block{})
+	c4 := s.AddBlock(rpc.Block{})
+
+	expectEvents(t, w, disconnected(b3), disconnected(b2), connected(c2), connected(c3), connected(c4))
+}
+
+func TestWatcherResume(t *testing.T) {
+	s := rpctest.NewServer()
+	defer s.Close()
+
+	s.AddBlocks(2)
+	b2 := s.AddBlock(rpc.Block{})
+	b3 := s.AddBlock(rpc.Block{})
+
+	w, cancel := newWatcher(t, s, &rpc.WatcherConfig{StartHeight: 3, ResumeHash: b2.BlockHash})
+	defer cancel()
+
+	expectEvents(t, w, connected(b3))
+}
+
+func TestWatcherResumeOrphaned(t *testing.T) {
+	s := rpctest.NewServer()
+	defer s.Close()
+
+	s.AddBlocks(3)
+
+	client, err := rpc.NewClient(s.Config())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The consumer processed a block at height 2 that the node no longer has.
+	orphan := ids.BlockHash(rpctest.NewID("ab", "orphan"))
+	w := rpc.NewWatcher(client, &rpc.WatcherConfig{
+		PollInterval: 5 * time.Millisecond,
+		StartHeight:  3,
+		ResumeHash:   orphan,
+	})
+
+	errc := make(chan error, 1)
+	go func() { errc <- w.Run(context.Background()) }()
+
+	expectEvents(t, w, rpc.BlockEvent{Type: rpc.BlockDisconnected, Height: 2, Hash: orphan})
+
+	// The history ends at the orphan, so the watcher cannot find the fork point.
+	if err := <-errc; !errors.Is(err, rpc.ErrReorgTooDeep) {
+		t.Fatalf("got %v want %v", err, rpc.ErrReorgTooDeep)
+	}
+}
+
+func TestWatcherValidator(t *testing.T) {
+	s := rpctest.NewServer()
+	defer s.Close()
+
+	b0 := s.AddBlock(rpc.Block{})
+	b1 := s.AddBlock(rpc.Block{})
+
+	// A lying node serves a block from the future.
+	future := time.Now().Add(24 * time.Hour).Unix()
+	s.AddBlock(rpc.Block{BlockHeader: rpc.BlockHeader{Metadata: rpc.BlockHeaderMetadata{Timestamp: future}}})
+
+	errs := make(chan error, 10)
+	w, cancel := newWatcher(t, s, &rpc.WatcherConfig{
+		Validator: rpc.NewHeaderValidator(&rpc.ValidatorConfig{}),
+		OnError: func(err error) {
+			select {
+			case errs <- err:
+			default:
+			}
+		},
+	})
+	defer cancel()
+
+	expectEvents(t, w, connected(b0), connected(b1))
+
+	if err := <-errs; !errors.Is(err, rpc.ErrInvalidBlock) {
+		t.Fatalf("got %v want %v", err, rpc.ErrInvalidBlock)
+	}
+
+	s.Rewind(1)
+	b2 := s.AddBlock(rpc.Block{})
+	expectEvents(t, w, connected(b2))
+}
@@ -42,3 +42,17 @@ func newTransferTransaction(privateKey *account.PrivateKey, to *account.Address,
 
 	return C.GoString(txn), nil
 }
+
+func transactionJSON(txn string) (string, error) {
+	cTxn := C.CString(txn)
+	defer C.free(unsafe.Pointer(cTxn))
+
+	res := C.transaction_json(cTxn)
+	if res == nil {
+		return "", handleCError()
+	}
+
+	defer C.free(unsafe.Pointer(res))
+
+	return C.GoString(res), nil
+}
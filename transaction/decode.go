@@ -0,0 +1,151 @@
+package transaction
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/btcsuite/btcutil/bech32"
+	"github.com/pinestreetlabs/aleo-wallet-sdk/ids"
+	"github.com/pinestreetlabs/aleo-wallet-sdk/rpc"
+)
+
+// Sizes of the fixed-width fields in a serialized testnet2 transaction.
+const (
+	innerCircuitIDSize = 48
+	ledgerRootSize     = 32
+	serialNumberSize   = 32
+	commitmentSize     = 32
+	ciphertextSize     = 320
+	proofSize          = 289
+
+	numInputRecords  = 2
+	numOutputRecords = 2
+)
+
+// Human-readable prefixes of the bech32m encoded fields.
+const (
+	innerCircuitIDPrefix = "ic"
+	ledgerRootPrefix     = "al"
+	serialNumberPrefix   = "sn"
+	commitmentPrefix     = "cm"
+	proofPrefix          = "ozkp"
+)
+
+var errTrailingBytes = errors.New("trailing bytes")
+var errUnexpectedEOF = errors.New("unexpected end of transaction")
+var errUnsupportedEvents = errors.New("transition events are not supported")
+
+// DecodeTransaction decodes a hex encoded testnet2 transaction, such as the one returned by NewTransferTransaction.
+// Transaction, transition and ciphertext IDs are hashes of the decoded fields and are left empty; see ComputeIDs.
+func DecodeTransaction(txn string) (*rpc.Transaction, error) {
+	raw, err := hex.DecodeString(txn)
+	if err != nil {
+		return nil, fmt.Errorf("DecodeTransaction : %w", err)
+	}
+
+	d := &decoder{buf: raw}
+
+	tx := &rpc.Transaction{
+		InnerCircuitID: d.bech32m(innerCircuitIDPrefix, innerCircuitIDSize),
+		LedgerRoot:     ids.LedgerRoot(d.bech32m(ledgerRootPrefix, ledgerRootSize)),
+	}
+
+	n := d.uint16()
+	for i := 0; i < int(n) && d.err == nil; i++ {
+		tx.Transitions = append(tx.Transitions, d.transition())
+	}
+
+	if d.err == nil && len(d.buf) != 0 {
+		d.err = fmt.Errorf("%w : %d", errTrailingBytes, len(d.buf))
+	}
+
+	if d.err != nil {
+		return nil, fmt.Errorf("DecodeTransaction : %w", d.err)
+	}
+
+	return tx, nil
+}
+
+// decoder reads little-endian fields from buf, keeping the first error.
+type decoder struct {
+	buf []byte
+	err error
+}
+
+func (d *decoder) transition() rpc.Transition {
+	var t rpc.Transition
+
+	for i := 0; i < numInputRecords; i++ {
+		t.SerialNumbers = append(t.SerialNumbers, ids.SerialNumber(d.bech32m(serialNumberPrefix, serialNumberSize)))
+	}
+
+	for i := 0; i < numOutputRecords; i++ {
+		t.Commitments = append(t.Commitments, ids.Commitment(d.bech32m(commitmentPrefix, commitmentSize)))
+	}
+
+	for i := 0; i < numOutputRecords; i++ {
+		t.Ciphertexts = append(t.Ciphertexts, hex.EncodeToString(d.next(ciphertextSize)))
+	}
+
+	t.ValueBalance = int64(d.uint64())
+	t.Proof = d.bech32m(proofPrefix, proofSize)
+
+	if n := d.uint16(); n != 0 && d.err == nil {
+		d.err = fmt.Errorf("%w : got %d", errUnsupportedEvents, n)
+	}
+
+	return t
+}
+
+func (d *decoder) next(n int) []byte {
+	if d.err != nil {
+		return nil
+	}
+
+	if len(d.buf) < n {
+		d.err = errUnexpectedEOF
+		return nil
+	}
+
+	b := d.buf[:n]
+	d.buf = d.buf[n:]
+	return b
+}
+
+func (d *decoder) uint16() uint16 {
+	b := d.next(2)
+	if b == nil {
+		return 0
+	}
+	return binary.LittleEndian.Uint16(b)
+}
+
+func (d *decoder) uint64() uint64 {
+	b := d.next(8)
+	if b == nil {
+		return 0
+	}
+	return binary.LittleEndian.Uint64(b)
+}
+
+func (d *decoder) bech32m(prefix string, n int) string {
+	b := d.next(n)
+	if b == nil {
+		return ""
+	}
+
+	data, err := bech32.ConvertBits(b, 8, 5, true)
+	if err != nil {
+		d.err = err
+		return ""
+	}
+
+	s, err := bech32.EncodeM(prefix, data)
+	if err != nil {
+		d.err = err
+		return ""
+	}
+
+	return s
+}
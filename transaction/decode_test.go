@@ -0,0 +1,61 @@
+package transaction
+
+import (
+	"errors"
+	"github.com/pinestreetlabs/aleo-wallet-sdk/ids"
+	"github.com/pinestreetlabs/aleo-wallet-sdk/rpc"
+	"reflect"
+	"testing"
+)
+
+// testTransaction is the transfer broadcast in doc/getting_started.md.
+const testTransaction = "a8a2358b0aa49123434b1757172e034d119cc282cfceb42210f886920ae6754d043539a82da297d5578504fcab00330074b29e65a6c941eb642a0c0a58aa40fd1b0bd8b4547f7b208e360fe088d1b60901005b6970fce912cd7c5cbe53cd7a74eb2379c6e495f031fc95a4ac70d20d770504a46a9afcc132af6edffa4bb3ee80da936eeb432d0c7a789f0d33156585e4fc01eabc12c3eb5347f1f78a560b9a84f47fa890b9b1e7d07f896c3d9288c795bf0ab2dc6565f59941a7ce4b951d1714e73a74c8a9bf94bdefed398188473bf2781062805f969e27bacc15dc88d572a94a324893809fdda9911dcf82675e9db32011850856350acf4dd6aa19bfcac4f146808046c66aadbf1bd433f77a58e000980f60bd0b7981dddc20c37717e9f830c89ee9eca669ed513b811b6ee1eb36399d0664b2879f1ab26244ddfe51aacc990d2de195a140baf392e7b33cc670056b350fe8f52e971368a56f7020394f223daa7467eb34ea7063a5b47fcdac99fc9efd0bf274c904d2251af9308f4aa555272c9691a1b770e8dc593d2c593ef23a958503d6dd9cece9577c7adb9b40b1d8c8c596b468bdec711e3318a884f49b8fbfbe0ccb92a4350172b7b05a453e2e5c269f8a4efebc20f5696cb7e5c9d99eb71f031068fcfb057dddc336dc9014871a13d93ba0f4ec636a5facf2b1fa4d7dbb9046094ef7e3a4122512826bc9226d000a90b3048da23dbc2d9fe486d68f5bbe76cd0d0d80c60a11fdb7e57fd80f603d623863fc1ec44d4758792e667093ad508dad044a15b8c3dbf092a7c588282243c3ad411cee1f6e799c0ed4f2efc77d099711088e2152af75c8f11e40ec2988989666c2c8a8ac197c7a27940c7bac2fd7f55a11f4b752b71c40e31acabbb8feb6ec9156d2eea1d5c5a0751c4b0d543700cb1601acce9e530798e4670f173e8beb6612a17313e137aee5c7c4d40f82d99a782f090d8ef2b8b017c1953a9bda74251fd847ec479b70886c0886122d052c2e227a12f0060dfeb55f336158c289df991ab3295d46db87d1657b88d3bc083c7872620126027b791dfe52c3c9202573560cfc954871c5f639630b4f6508ebca17d6e30ca7c40d19e5cf13949fed7c59d7b6fcdea5afef3d0bef312d54caefade2a3cb10bb284cc522e3b76fd56589f02c1e44fb69584305069a3f50aa516758da01701040420f0000000000950c476fd6d1a62bbb1bb014409a48f55e50ed97ccce27cfc010dd485434d383bf5a11544e955ecd5bf1a4c7fd4d249ccf9179fd4e0daad0e84fe55a4e3d091a7065a94b25fc328b0908cefc166a40d7e75adebdbab85154159ace4cc4ccfe00835d481947d8d0c69041c8f3262818d4fb6bac2512ddeab7261b5acd3fbac3545787614edf2107f575eedb55cc9be7a1aac4fe6584163cb12a3396ffe3b502dfd5fa8e2e5ff261f974651a8019f45f81256cb92cc05a5cb5e8fe6b9d2e36db801b2cd7f56412dcaec70a9fb638de9b14d058cece9e4e041cccc118afaa89362e4f0cdadefb9ca2fe3bdcf9f455390a735150a0bb57415e7a30cf3f1e578161e29b9cd2e371eaef2c753ab316d081abc043b02d07144cb045ded36b6999a95d00010000"
+
+func TestDecodeTransaction(t *testing.T) {
+	tx, err := DecodeTransaction(testTransaction)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &rpc.Transaction{
+		LedgerRoot:     "al1wjefuedxe9q7kep2ps9932jql5dshk9523lhkgywxc87pzx3kcysuz0439",
+		InnerCircuitID: "ic14z3rtzc25jgjxs6tzat3wtsrf5gees5zel8tggsslzrfyzhxw4xsgdfe4qk6997427zsfl9tqqesq5fzw5q",
+		Transitions: []rpc.Transition{{
+			Ciphertexts: []string{
+				"62805f969e27bacc15dc88d572a94a324893809fdda9911dcf82675e9db32011850856350acf4dd6aa19bfcac4f146808046c66aadbf1bd433f77a58e000980f60bd0b7981dddc20c37717e9f830c89ee9eca669ed513b811b6ee1eb36399d0664b2879f1ab26244ddfe51aacc990d2de195a140baf392e7b33cc670056b350fe8f52e971368a56f7020394f223daa7467eb34ea7063a5b47fcdac99fc9efd0bf274c904d2251af9308f4aa555272c9691a1b770e8dc593d2c593ef23a958503d6dd9cece9577c7adb9b40b1d8c8c596b468bdec711e3318a884f49b8fbfbe0ccb92a4350172b7b05a453e2e5c269f8a4efebc20f5696cb7e5c9d99eb71f031068fcfb057dddc336dc9014871a13d93ba0f4ec636a5facf2b1fa4d7dbb9046094ef7e3a4122512826bc9226d000a90b3048da23dbc2d9fe486d68f5bbe76cd0d",
+				"0d80c60a11fdb7e57fd80f603d623863fc1ec44d4758792e667093ad508dad044a15b8c3dbf092a7c588282243c3ad411cee1f6e799c0ed4f2efc77d099711088e2152af75c8f11e40ec2988989666c2c8a8ac197c7a27940c7bac2fd7f55a11f4b752b71c40e31acabbb8feb6ec9156d2eea1d5c5a0751c4b0d543700cb1601acce9e530798e4670f173e8beb6612a17313e137aee5c7c4d40f82d99a782f090d8ef2b8b017c1953a9bda74251fd847ec479b70886c0886122d052c2e227a12f0060dfeb55f336158c289df991ab3295d46db87d1657b88d3bc083c7872620126027b791dfe52c3c9202573560cfc954871c5f639630b4f6508ebca17d6e30ca7c40d19e5cf13949fed7c59d7b6fcdea5afef3d0bef312d54caefade2a3cb10bb284cc522e3b76fd56589f02c1e44fb69584305069a3f50aa516758da017010",
+			},
+			Commitments:   []ids.Commitment{"cm1a27p9slt2drlrau22c9e4p85075fpwd3ulg8lztv8kfg33u4hu9qtt282d", "cm1ktwx2e04n9q60njtj5w3w9888f6v32dljj77lmfesxyywwlj0qgq74v656"},
+			Proof:         "ozkp1j5xywm7k6xnzhwcmkq2ypxjg7409pmvhen8z0n7qzrw5s4p56wpm7ks3238f2hkdt0c6f3laf5jfenu30875urd26r5yle26fc7sjxnsvk55kf0ux29sjzxwlstx5sxhuadda0d6hpg4g9v6eexvfn87qzp46jqeglvdp35sg8y0xf3grr20k6avy5fdm64hycd44nflhtp4g4u8v98d7gg87467ak64ejd70gd2cnlxtpqk8jcj5vukll3m2qkl6hagutjl7fsljar9r2qpnazlsyjkewfvcpd9ed0gle4e6t3kmwqpktxh74jp9h9wcu9fld3cm6d3f5zcem8funsyrnxvzx9042ynvtj0pndda7uu5tlrhh8e732njznn29g2pw6hg9085vx08u090qtpu2dee5hrw84w7tr482e3d5yp40qy8vpdqu2yevz9mmfkk6ve49wsqqgj45z2u",
+			SerialNumbers: []ids.SerialNumber{"sn1td5hpl8fztxhch9720xh5a8tyduudey47qcle9dy43cdyrthq5zqqjyg35", "sn1534f4lxpx2hkahl6fwe7aqx6jdhwksedp3a838cdxv2ktp0ylsqsnspkk8"},
+			ValueBalance:  1000000,
+		}},
+	}
+
+	if !reflect.DeepEqual(tx, expected) {
+		t.Fatalf("got %+v want %+v", tx, expected)
+	}
+}
+
+func TestDecodeTransactionInvalid(t *testing.T) {
+	tests := map[string]struct {
+		txn string
+		err error
+	}{
+		"truncated": {txn: testTransaction[:len(testTransaction)-10], err: errUnexpectedEOF},
+		"trailing":  {txn: testTransaction + "00", err: errTrailingBytes},
+		"events":    {txn: testTransaction[:len(testTransaction)-4] + "0100", err: errUnsupportedEvents},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := DecodeTransaction(test.txn); !errors.Is(err, test.err) {
+				t.Fatalf("got %v want %v", err, test.err)
+			}
+		})
+	}
+
+	if _, err := DecodeTransaction("zz"); err == nil {
+		t.Fatal("expected err")
+	}
+}
@@ -0,0 +1,67 @@
+package transaction
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/pinestreetlabs/aleo-wallet-sdk/ids"
+	"github.com/pinestreetlabs/aleo-wallet-sdk/rpc"
+)
+
+// IDs holds the identifiers derived from a serialized transaction.
+type IDs struct {
+	TransactionID ids.TransactionID
+	Transitions   []TransitionIDs
+}
+
+// TransitionIDs holds the identifiers of a single transition.
+type TransitionIDs struct {
+	TransitionID  ids.TransitionID
+	CiphertextIDs []ids.CiphertextID
+}
+
+// ComputeIDs derives the transaction, transition and ciphertext IDs of a hex encoded transaction offline,
+// so they can be persisted before the transaction is broadcast.
+func ComputeIDs(txn string) (*IDs, error) {
+	js, err := transactionJSON(txn)
+	if err != nil {
+		return nil, fmt.Errorf("ComputeIDs : %w", err)
+	}
+
+	var tx rpc.Transaction
+	if err := json.Unmarshal([]byte(js), &tx); err != nil {
+		return nil, fmt.Errorf("ComputeIDs : %w", err)
+	}
+
+	res := &IDs{TransactionID: tx.TxID}
+	for _, t := range tx.Transitions {
+		res.Transitions = append(res.Transitions, TransitionIDs{TransitionID: t.ID, CiphertextIDs: t.CiphertextIDs})
+	}
+
+	return res, nil
+}
+
+// TransactionID derives the ID of a hex encoded transaction offline.
+// It can be used as rpc.RetryPolicy.TransactionID.
+func TransactionID(txn string) (ids.TransactionID, error) {
+	res, err := ComputeIDs(txn)
+	if err != nil {
+		return "", fmt.Errorf("TransactionID : %w", err)
+	}
+
+	return res.TransactionID, nil
+}
+
+// Apply sets the IDs on a decoded transaction, such as the one returned by DecodeTransaction.
+func (x *IDs) Apply(tx *rpc.Transaction) error {
+	if len(x.Transitions) != len(tx.Transitions) {
+		return fmt.Errorf("Apply : got %d transitions want %d", len(tx.Transitions), len(x.Transitions))
+	}
+
+	tx.TxID = x.TransactionID
+	for i, t := range x.Transitions {
+		tx.Transitions[i].ID = t.TransitionID
+		tx.Transitions[i].CiphertextIDs = t.CiphertextIDs
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package transaction
+
+import (
+	"github.com/pinestreetlabs/aleo-wallet-sdk/ids"
+	"reflect"
+	"testing"
+)
+
+func TestComputeIDs(t *testing.T) {
+	res, err := ComputeIDs(testTransaction)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &IDs{
+		TransactionID: "at1s8xaeuw706ruzc56v2nfum6l9ahwmruxp9ppjeqm93sr7phv6ursmsm9s3",
+		Transitions: []TransitionIDs{{
+			TransitionID:  "as1lte63rhyl2lf80u2fg72v2ew0v5qehj8wwssgw0zvaa3kkn8lv8q9g4w4d",
+			CiphertextIDs: []ids.CiphertextID{"ar10uy00r2jneuqqkkfulgmchdrrv360sv4tsr7sv5h68j32g7p5gqqg5j6a0", "ar1t4xmpy68yktxr2fegnlfqsyf254ymw8ec0lyt7hxffgkf4u655rs3ms2gd"},
+		}},
+	}
+
+	if !reflect.DeepEqual(res, expected) {
+		t.Fatalf("got %+v want %+v", res, expected)
+	}
+
+	id, err := TransactionID(testTransaction)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if id != expected.TransactionID {
+		t.Fatalf("got %s want %s", id, expected.TransactionID)
+	}
+
+	tx, err := DecodeTransaction(testTransaction)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := res.Apply(tx); err != nil {
+		t.Fatal(err)
+	}
+
+	if tx.TxID != expected.TransactionID || tx.Transitions[0].ID != expected.Transitions[0].TransitionID {
+		t.Fatalf("unexpected transaction %+v", tx)
+	}
+}
+
+func TestComputeIDsInvalid(t *testing.T) {
+	if _, err := ComputeIDs("zz"); err == nil {
+		t.Fatal("expected err")
+	}
+}